@@ -8,7 +8,9 @@ import (
 )
 
 type PluginSettings struct {
-	Secrets *SecretPluginSettings `json:"-"`
+	// GrossMarginPercent scales ARPU in LTV; unset means 100
+	GrossMarginPercent float64               `json:"grossMarginPercent"`
+	Secrets            *SecretPluginSettings `json:"-"`
 }
 
 type SecretPluginSettings struct {
@@ -16,12 +18,16 @@ type SecretPluginSettings struct {
 }
 
 func LoadPluginSettings(source backend.DataSourceInstanceSettings) (*PluginSettings, error) {
-	settings := PluginSettings{}
+	// An unset gross margin keeps the default
+	settings := PluginSettings{GrossMarginPercent: 100}
 	if len(source.JSONData) > 0 {
 		if err := json.Unmarshal(source.JSONData, &settings); err != nil {
 			return nil, fmt.Errorf("could not unmarshal PluginSettings json: %w", err)
 		}
 	}
+	if settings.GrossMarginPercent <= 0 || settings.GrossMarginPercent > 100 {
+		return nil, fmt.Errorf("gross margin must be above 0 and at most 100%%, got %v", settings.GrossMarginPercent)
+	}
 	settings.Secrets = loadSecretPluginSettings(source.DecryptedSecureJSONData)
 	return &settings, nil
 }
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+)
+
+func TestLoadPluginSettingsGrossMargin(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    float64
+		wantErr bool
+	}{
+		{"unset", `{}`, 100, false},
+		{"set", `{"grossMarginPercent": 80}`, 80, false},
+		{"zero", `{"grossMarginPercent": 0}`, 0, true},
+		{"above 100", `{"grossMarginPercent": 120}`, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings, err := LoadPluginSettings(backend.DataSourceInstanceSettings{JSONData: []byte(tt.json)})
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got gross margin %v", settings.GrossMarginPercent)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if settings.GrossMarginPercent != tt.want {
+				t.Errorf("got %v, want %v", settings.GrossMarginPercent, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/jfreels123/stripe-datasource/pkg/stripe"
+)
+
+// queryAnnotations returns Stripe events in the panel time range as a frame
+// Grafana maps to annotations by its time, title, text and tags fields
+func (d *Datasource) queryAnnotations(ctx context.Context, q backend.DataQuery, qm queryModel) backend.DataResponse {
+	events, hasMore, err := d.client.GetEvents(ctx, qm.eventFilter(q))
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	n := len(events)
+	times := make([]time.Time, n)
+	titles := make([]string, n)
+	texts := make([]string, n)
+	tags := make([]string, n)
+	for i, e := range events {
+		times[i] = e.Created
+		titles[i] = eventTitle(e)
+		texts[i] = eventText(e)
+		tags[i] = strings.Join(eventTags(e), ",")
+	}
+
+	frame := data.NewFrame("annotations",
+		data.NewField("time", nil, times),
+		data.NewField("title", nil, titles),
+		data.NewField("text", nil, texts),
+		data.NewField("tags", nil, tags),
+	)
+	if hasMore {
+		addNotice(frame, fmt.Sprintf("Showing the latest %d events; raise the limit or narrow the event types for more.", n))
+	}
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
+
+// eventTitle names the event and, when it has one, its amount
+func eventTitle(e stripe.EventData) string {
+	if !e.HasAmount {
+		return e.Type
+	}
+	decimals := 2
+	if stripe.ZeroDecimal(e.Currency) {
+		decimals = 0
+	}
+	return fmt.Sprintf("%s: %.*f %s", e.Type, decimals, stripe.CurrencyUnits(e.Amount, e.Currency), strings.ToUpper(e.Currency))
+}
+
+// eventText lists the event, object and customer IDs
+func eventText(e stripe.EventData) string {
+	parts := []string{e.ID}
+	if e.ObjectID != "" {
+		parts = append(parts, e.ObjectID)
+	}
+	if e.Customer != "" {
+		parts = append(parts, e.Customer)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// eventTags tags every annotation with "stripe", the object type and the
+// event type
+func eventTags(e stripe.EventData) []string {
+	tags := []string{"stripe"}
+	if e.ObjectType != "" {
+		tags = append(tags, e.ObjectType)
+	}
+	return append(tags, e.Type)
+}
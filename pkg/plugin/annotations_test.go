@@ -0,0 +1,26 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/jfreels123/stripe-datasource/pkg/stripe"
+)
+
+func TestEventTitle(t *testing.T) {
+	tests := []struct {
+		name  string
+		event stripe.EventData
+		want  string
+	}{
+		{"no amount", stripe.EventData{Type: "payout.failed"}, "payout.failed"},
+		{"cents", stripe.EventData{Type: "charge.succeeded", HasAmount: true, Amount: 12345, Currency: "usd"}, "charge.succeeded: 123.45 USD"},
+		{"zero decimal", stripe.EventData{Type: "charge.succeeded", HasAmount: true, Amount: 5000, Currency: "jpy"}, "charge.succeeded: 5000 JPY"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := eventTitle(tt.event); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/jfreels123/stripe-datasource/pkg/stripe"
+)
+
+// queryCheckoutSessions returns checkout session counts, conversion and
+// revenue per bucket over the panel range, one row per bucket and segment
+func (d *Datasource) queryCheckoutSessions(ctx context.Context, q backend.DataQuery, qm queryModel) backend.DataResponse {
+	switch qm.GroupBy {
+	case "", stripe.CheckoutByPrice, stripe.CheckoutByPaymentLink:
+	default:
+		return backend.ErrDataResponse(backend.StatusBadRequest, fmt.Sprintf("checkout sessions cannot be grouped by %q", qm.GroupBy))
+	}
+	interval, err := bucketInterval(qm, q)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusBadRequest, err.Error())
+	}
+
+	rows, err := d.client.GetCheckoutSessions(ctx, q.TimeRange.From, q.TimeRange.To, interval, qm.GroupBy)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	frame := data.NewFrame("checkout_sessions")
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualization: data.VisTypeTable,
+	}
+
+	n := len(rows)
+	times := make([]time.Time, n)
+	segments := make([]string, n)
+	sessions := make([]int64, n)
+	open := make([]int64, n)
+	complete := make([]int64, n)
+	expired := make([]int64, n)
+	paid := make([]int64, n)
+	unpaid := make([]int64, n)
+	noPayment := make([]int64, n)
+	conversion := make([]float64, n)
+	revenue := make([]float64, n)
+
+	for i, r := range rows {
+		times[i] = r.Time
+		segments[i] = r.Segment
+		sessions[i] = r.Sessions
+		open[i] = r.Open
+		complete[i] = r.Complete
+		expired[i] = r.Expired
+		paid[i] = r.Paid
+		unpaid[i] = r.Unpaid
+		noPayment[i] = r.NoPaymentRequired
+		conversion[i] = r.ConversionRate
+		revenue[i] = float64(r.Revenue) / 100
+	}
+
+	frame.Fields = append(frame.Fields,
+		data.NewField("time", nil, times),
+		data.NewField("segment", nil, segments),
+		data.NewField("sessions", nil, sessions),
+		data.NewField("open", nil, open),
+		data.NewField("complete", nil, complete),
+		data.NewField("expired", nil, expired),
+		data.NewField("paid", nil, paid),
+		data.NewField("unpaid", nil, unpaid),
+		data.NewField("no_payment_required", nil, noPayment),
+		data.NewField("conversion_rate", nil, conversion),
+		data.NewField("revenue", nil, revenue),
+	)
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
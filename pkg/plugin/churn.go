@@ -0,0 +1,115 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+func (d *Datasource) queryChurn(ctx context.Context, q backend.DataQuery, queryType QueryType) backend.DataResponse {
+	end := time.Now()
+	if q.TimeRange.To.Before(end) {
+		end = q.TimeRange.To
+	}
+
+	churn, err := d.client.GetChurn(ctx, q.TimeRange.From, end)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	frame := data.NewFrame("metrics")
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualizationPluginID: "stat",
+	}
+
+	var value float64
+	var name string
+
+	switch queryType {
+	case QueryRevenueChurnRate:
+		value = churn.RevenueChurnRate()
+		name = "Revenue Churn %"
+	case QueryVoluntaryChurnRate:
+		value = churn.VoluntaryChurnRate()
+		name = "Voluntary Churn %"
+	case QueryInvoluntaryChurnRate:
+		value = churn.InvoluntaryChurnRate()
+		name = "Involuntary Churn %"
+	default:
+		value = churn.LogoChurnRate()
+		name = "Logo Churn %"
+	}
+
+	frame.Fields = append(frame.Fields,
+		data.NewField("time", nil, []time.Time{end}),
+		data.NewField(name, nil, []float64{value}),
+	)
+
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
+
+func (d *Datasource) queryChurnReasons(ctx context.Context, q backend.DataQuery) backend.DataResponse {
+	reasons, err := d.client.GetCancellationBreakdown(ctx, q.TimeRange.From, q.TimeRange.To)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	frame := data.NewFrame("churn_reasons")
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualization: data.VisTypeTable,
+	}
+
+	types := make([]string, len(reasons))
+	causes := make([]string, len(reasons))
+	feedback := make([]string, len(reasons))
+	counts := make([]int64, len(reasons))
+	mrrs := make([]float64, len(reasons))
+
+	for i, r := range reasons {
+		types[i] = r.Type
+		causes[i] = r.Reason
+		feedback[i] = r.Feedback
+		counts[i] = r.Count
+		mrrs[i] = float64(r.MRR) / 100
+	}
+
+	frame.Fields = append(frame.Fields,
+		data.NewField("type", nil, types),
+		data.NewField("reason", nil, causes),
+		data.NewField("feedback", nil, feedback),
+		data.NewField("subscriptions", nil, counts),
+		data.NewField("mrr", nil, mrrs),
+	)
+
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
+
+func (d *Datasource) queryPendingChurn(ctx context.Context, q backend.DataQuery) backend.DataResponse {
+	pending, err := d.client.GetPendingChurn(ctx)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	frame := data.NewFrame("pending_churn")
+
+	months := make([]time.Time, len(pending))
+	counts := make([]int64, len(pending))
+	mrrs := make([]float64, len(pending))
+
+	for i, p := range pending {
+		months[i] = p.Month
+		counts[i] = p.Count
+		mrrs[i] = float64(p.MRR) / 100
+	}
+
+	frame.Fields = append(frame.Fields,
+		data.NewField("time", nil, months),
+		data.NewField("subscriptions", nil, counts),
+		data.NewField("Pending Churn MRR", nil, mrrs),
+	)
+
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
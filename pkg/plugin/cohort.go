@@ -0,0 +1,64 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/jfreels123/stripe-datasource/pkg/stripe"
+)
+
+func (d *Datasource) queryCohortRetention(ctx context.Context, q backend.DataQuery) backend.DataResponse {
+	cohorts, err := d.client.GetCohortRetention(ctx, q.TimeRange.From, q.TimeRange.To)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	logo := cohortFrame("logo_retention", cohorts, func(c stripe.CohortData) []float64 { return c.LogoRetention })
+	revenue := cohortFrame("revenue_retention", cohorts, func(c stripe.CohortData) []float64 { return c.RevenueRetention })
+
+	return backend.DataResponse{Frames: []*data.Frame{logo, revenue}}
+}
+
+// cohortFrame builds a cohort-by-month matrix with one row per cohort and one
+// column per month since signup. Months a cohort has not reached yet are null.
+func cohortFrame(name string, cohorts []stripe.CohortData, values func(stripe.CohortData) []float64) *data.Frame {
+	frame := data.NewFrame(name)
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualization: data.VisTypeTable,
+	}
+
+	months := 0
+	for _, c := range cohorts {
+		months = max(months, len(values(c)))
+	}
+
+	starts := make([]time.Time, len(cohorts))
+	customers := make([]int64, len(cohorts))
+	startingMRR := make([]float64, len(cohorts))
+	columns := make([][]*float64, months)
+	for m := range columns {
+		columns[m] = make([]*float64, len(cohorts))
+	}
+
+	for i, c := range cohorts {
+		starts[i] = c.Cohort
+		customers[i] = c.Customers
+		startingMRR[i] = float64(c.StartingMRR) / 100
+		for m, v := range values(c) {
+			columns[m][i] = &v
+		}
+	}
+
+	frame.Fields = append(frame.Fields,
+		data.NewField("cohort", nil, starts),
+		data.NewField("customers", nil, customers),
+		data.NewField("starting_mrr", nil, startingMRR),
+	)
+	for m, col := range columns {
+		frame.Fields = append(frame.Fields, data.NewField(fmt.Sprintf("month_%d", m), nil, col))
+	}
+	return frame
+}
@@ -0,0 +1,77 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+func (d *Datasource) queryTopCustomers(ctx context.Context, q backend.DataQuery, qm queryModel) backend.DataResponse {
+	byRevenue := qm.RankBy == "revenue"
+	customers, err := d.client.GetTopCustomers(ctx, byRevenue, q.TimeRange.From, q.TimeRange.To, qm.Limit)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	frame := data.NewFrame("top_customers")
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualization: data.VisTypeTable,
+	}
+
+	ids := make([]string, len(customers))
+	emails := make([]string, len(customers))
+	names := make([]string, len(customers))
+	mrrs := make([]float64, len(customers))
+	revenues := make([]float64, len(customers))
+	shares := make([]float64, len(customers))
+
+	for i, c := range customers {
+		ids[i] = c.Customer
+		emails[i] = c.Email
+		names[i] = c.Name
+		mrrs[i] = float64(c.MRR) / 100
+		revenues[i] = float64(c.Revenue) / 100
+		shares[i] = c.Share
+	}
+
+	frame.Fields = append(frame.Fields,
+		data.NewField("customer", nil, ids),
+		data.NewField("email", nil, emails),
+		data.NewField("name", nil, names),
+		data.NewField("mrr", nil, mrrs),
+	)
+	if byRevenue {
+		frame.Fields = append(frame.Fields, data.NewField("revenue", nil, revenues))
+	}
+	frame.Fields = append(frame.Fields, data.NewField("share", nil, shares))
+
+	if err := sortFrame(frame, qm.SortBy, qm.SortDirection); err != nil {
+		return backend.ErrDataResponse(backend.StatusBadRequest, err.Error())
+	}
+
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
+
+func (d *Datasource) queryConcentration(ctx context.Context, q backend.DataQuery) backend.DataResponse {
+	conc, err := d.client.GetConcentration(ctx)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	frame := data.NewFrame("concentration")
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualizationPluginID: "stat",
+	}
+	frame.Fields = append(frame.Fields,
+		data.NewField("time", nil, []time.Time{time.Now()}),
+		data.NewField("Top Customer %", nil, []float64{conc.Top1Share}),
+		data.NewField("Top 10 Customers %", nil, []float64{conc.Top10Share}),
+		data.NewField("Top 10% of Customers %", nil, []float64{conc.TopDecileShare}),
+		data.NewField("HHI", nil, []float64{conc.HHI}),
+	)
+
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
@@ -0,0 +1,75 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// queryCoupons returns coupons and promotion codes with their redemptions and
+// the MRR they discount on active subscriptions
+func (d *Datasource) queryCoupons(ctx context.Context, q backend.DataQuery) backend.DataResponse {
+	coupons, err := d.client.GetCoupons(ctx)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	frame := data.NewFrame("coupons")
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualization: data.VisTypeTable,
+	}
+
+	n := len(coupons)
+	ids := make([]string, n)
+	names := make([]string, n)
+	promoIDs := make([]string, n)
+	codes := make([]string, n)
+	percentOff := make([]float64, n)
+	amountOff := make([]float64, n)
+	durations := make([]string, n)
+	active := make([]bool, n)
+	redeemed := make([]int64, n)
+	maxRedemptions := make([]int64, n)
+	subs := make([]int64, n)
+	listMRR := make([]float64, n)
+	discountedMRR := make([]float64, n)
+	mrrDiscount := make([]float64, n)
+
+	for i, c := range coupons {
+		ids[i] = c.Coupon
+		names[i] = c.Name
+		promoIDs[i] = c.PromotionCode
+		codes[i] = c.Code
+		percentOff[i] = c.PercentOff
+		amountOff[i] = float64(c.AmountOff) / 100
+		durations[i] = c.Duration
+		active[i] = c.Active
+		redeemed[i] = c.TimesRedeemed
+		maxRedemptions[i] = c.MaxRedemptions
+		subs[i] = c.ActiveSubscriptions
+		listMRR[i] = float64(c.ListMRR) / 100
+		discountedMRR[i] = float64(c.DiscountedMRR) / 100
+		mrrDiscount[i] = float64(c.MRRDiscount) / 100
+	}
+
+	frame.Fields = append(frame.Fields,
+		data.NewField("coupon", nil, ids),
+		data.NewField("name", nil, names),
+		data.NewField("promotion_code", nil, promoIDs),
+		data.NewField("code", nil, codes),
+		data.NewField("percent_off", nil, percentOff),
+		data.NewField("amount_off", nil, amountOff),
+		data.NewField("duration", nil, durations),
+		data.NewField("active", nil, active),
+		data.NewField("times_redeemed", nil, redeemed),
+		data.NewField("max_redemptions", nil, maxRedemptions),
+		data.NewField("active_subscriptions", nil, subs),
+		data.NewField("list_mrr", nil, listMRR),
+		data.NewField("discounted_mrr", nil, discountedMRR),
+		data.NewField("mrr_discount", nil, mrrDiscount),
+	)
+
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
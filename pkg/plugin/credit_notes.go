@@ -0,0 +1,78 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// queryCreditNotes returns credit notes created in the panel time range
+func (d *Datasource) queryCreditNotes(ctx context.Context, q backend.DataQuery, qm queryModel) backend.DataResponse {
+	notes, hasMore, err := d.client.GetCreditNotes(ctx, q.TimeRange.From, q.TimeRange.To, qm.listOptions())
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	frame := data.NewFrame("credit_notes")
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualization: data.VisTypeTable,
+	}
+
+	n := len(notes)
+	ids := make([]string, n)
+	numbers := make([]string, n)
+	customers := make([]string, n)
+	invoices := make([]string, n)
+	statuses := make([]string, n)
+	types := make([]string, n)
+	reasons := make([]string, n)
+	amounts := make([]float64, n)
+	refunded := make([]float64, n)
+	outOfBand := make([]float64, n)
+	credited := make([]float64, n)
+	currencies := make([]string, n)
+	created := make([]time.Time, n)
+	memos := make([]string, n)
+
+	for i, cn := range notes {
+		ids[i] = cn.ID
+		numbers[i] = cn.Number
+		customers[i] = cn.Customer
+		invoices[i] = cn.Invoice
+		statuses[i] = cn.Status
+		types[i] = cn.Type
+		reasons[i] = cn.Reason
+		amounts[i] = float64(cn.Amount) / 100
+		refunded[i] = float64(cn.Refunded) / 100
+		outOfBand[i] = float64(cn.OutOfBand) / 100
+		credited[i] = float64(cn.Credited) / 100
+		currencies[i] = cn.Currency
+		created[i] = cn.Created
+		memos[i] = cn.Memo
+	}
+
+	frame.Fields = append(frame.Fields,
+		data.NewField("id", nil, ids),
+		data.NewField("number", nil, numbers),
+		data.NewField("customer", nil, customers),
+		data.NewField("invoice", nil, invoices),
+		data.NewField("status", nil, statuses),
+		data.NewField("type", nil, types),
+		data.NewField("reason", nil, reasons),
+		data.NewField("amount", nil, amounts),
+		data.NewField("refunded", nil, refunded),
+		data.NewField("out_of_band", nil, outOfBand),
+		data.NewField("credited", nil, credited),
+		data.NewField("currency", nil, currencies),
+		data.NewField("created", nil, created),
+		data.NewField("memo", nil, memos),
+	)
+
+	if err := applyTableOptions(frame, qm, hasMore); err != nil {
+		return backend.ErrDataResponse(backend.StatusBadRequest, err.Error())
+	}
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
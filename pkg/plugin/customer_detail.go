@@ -0,0 +1,127 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/jfreels123/stripe-datasource/pkg/stripe"
+)
+
+func (d *Datasource) queryCustomerDetail(ctx context.Context, q backend.DataQuery, qm queryModel) backend.DataResponse {
+	if qm.CustomerID == "" {
+		return backend.ErrDataResponse(backend.StatusBadRequest, "customer ID is required")
+	}
+
+	detail, err := d.client.GetCustomerDetail(ctx, qm.CustomerID)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	return backend.DataResponse{Frames: []*data.Frame{
+		subscriptionsFrame(detail.Subscriptions),
+		invoicesFrame(detail.Invoices),
+		chargesFrame(detail.Charges),
+		refundsFrame(detail.Refunds),
+		disputesFrame(detail.Disputes),
+		mrrHistoryFrame(detail.MRRHistory),
+	}}
+}
+
+// refundsFrame builds the refunds table frame
+func refundsFrame(refunds []stripe.RefundData) *data.Frame {
+	frame := data.NewFrame("refunds")
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualization: data.VisTypeTable,
+	}
+
+	ids := make([]string, len(refunds))
+	charges := make([]string, len(refunds))
+	amounts := make([]float64, len(refunds))
+	currencies := make([]string, len(refunds))
+	statuses := make([]string, len(refunds))
+	reasons := make([]string, len(refunds))
+	created := make([]time.Time, len(refunds))
+
+	for i, r := range refunds {
+		ids[i] = r.ID
+		charges[i] = r.Charge
+		amounts[i] = float64(r.Amount) / 100
+		currencies[i] = r.Currency
+		statuses[i] = r.Status
+		reasons[i] = r.Reason
+		created[i] = r.Created
+	}
+
+	frame.Fields = append(frame.Fields,
+		data.NewField("id", nil, ids),
+		data.NewField("charge", nil, charges),
+		data.NewField("amount", nil, amounts),
+		data.NewField("currency", nil, currencies),
+		data.NewField("status", nil, statuses),
+		data.NewField("reason", nil, reasons),
+		data.NewField("created", nil, created),
+	)
+
+	return frame
+}
+
+// disputesFrame builds the disputes table frame
+func disputesFrame(disputes []stripe.DisputeData) *data.Frame {
+	frame := data.NewFrame("disputes")
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualization: data.VisTypeTable,
+	}
+
+	ids := make([]string, len(disputes))
+	charges := make([]string, len(disputes))
+	amounts := make([]float64, len(disputes))
+	currencies := make([]string, len(disputes))
+	statuses := make([]string, len(disputes))
+	reasons := make([]string, len(disputes))
+	created := make([]time.Time, len(disputes))
+
+	for i, dp := range disputes {
+		ids[i] = dp.ID
+		charges[i] = dp.Charge
+		amounts[i] = float64(dp.Amount) / 100
+		currencies[i] = dp.Currency
+		statuses[i] = dp.Status
+		reasons[i] = dp.Reason
+		created[i] = dp.Created
+	}
+
+	frame.Fields = append(frame.Fields,
+		data.NewField("id", nil, ids),
+		data.NewField("charge", nil, charges),
+		data.NewField("amount", nil, amounts),
+		data.NewField("currency", nil, currencies),
+		data.NewField("status", nil, statuses),
+		data.NewField("reason", nil, reasons),
+		data.NewField("created", nil, created),
+	)
+
+	return frame
+}
+
+// mrrHistoryFrame builds a time series of monthly MRR
+func mrrHistoryFrame(points []stripe.MRRPoint) *data.Frame {
+	frame := data.NewFrame("mrr_history")
+
+	times := make([]time.Time, len(points))
+	mrrs := make([]float64, len(points))
+
+	for i, p := range points {
+		times[i] = p.Time
+		mrrs[i] = float64(p.MRR) / 100
+	}
+
+	frame.Fields = append(frame.Fields,
+		data.NewField("time", nil, times),
+		data.NewField("MRR", nil, mrrs),
+	)
+
+	return frame
+}
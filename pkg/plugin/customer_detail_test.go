@@ -0,0 +1,43 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/jfreels123/stripe-datasource/pkg/stripe"
+)
+
+func TestQueryCustomerDetailRequiresCustomer(t *testing.T) {
+	ds := Datasource{}
+	resp := ds.queryCustomerDetail(context.Background(), backend.DataQuery{}, queryModel{})
+	if resp.Status != backend.StatusBadRequest {
+		t.Errorf("got status %v, want bad request", resp.Status)
+	}
+}
+
+func TestCustomerDetailFrames(t *testing.T) {
+	created := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	refunds := refundsFrame([]stripe.RefundData{{ID: "re_1", Charge: "ch_1", Amount: 1250, Currency: "usd", Created: created}})
+	if refunds.Name != "refunds" || refunds.Rows() != 1 {
+		t.Fatalf("refunds: got frame %q with %d rows", refunds.Name, refunds.Rows())
+	}
+	if got := refunds.Fields[2].At(0).(float64); got != 12.5 {
+		t.Errorf("refund amount: got %v, want 12.5", got)
+	}
+
+	disputes := disputesFrame([]stripe.DisputeData{{ID: "dp_1", Charge: "ch_2", Amount: 4000, Currency: "usd", Created: created}})
+	if disputes.Name != "disputes" || disputes.Rows() != 1 || disputes.Fields[1].At(0).(string) != "ch_2" {
+		t.Errorf("disputes: got frame %q with %d rows", disputes.Name, disputes.Rows())
+	}
+
+	history := mrrHistoryFrame([]stripe.MRRPoint{{Time: created, MRR: 3000}, {Time: created.AddDate(0, 1, 0), MRR: 4500}})
+	if history.Name != "mrr_history" || history.Rows() != 2 {
+		t.Fatalf("mrr history: got frame %q with %d rows", history.Name, history.Rows())
+	}
+	if got := history.Fields[1].At(1).(float64); got != 45 {
+		t.Errorf("mrr history: got %v, want 45", got)
+	}
+}
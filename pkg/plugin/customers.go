@@ -0,0 +1,87 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/jfreels123/stripe-datasource/pkg/stripe"
+)
+
+func (d *Datasource) queryCustomerList(ctx context.Context, q backend.DataQuery, qm queryModel) backend.DataResponse {
+	customers, hasMore, err := d.client.GetCustomers(ctx, stripe.CustomerListOptions{
+		ListOptions: qm.listOptions(),
+		Search:      qm.Search,
+	})
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	frame := data.NewFrame("customers")
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualization: data.VisTypeTable,
+	}
+
+	ids := make([]string, len(customers))
+	emails := make([]string, len(customers))
+	names := make([]string, len(customers))
+	created := make([]time.Time, len(customers))
+	currencies := make([]string, len(customers))
+	delinquent := make([]bool, len(customers))
+	balances := make([]float64, len(customers))
+	activeSubs := make([]int64, len(customers))
+	mrrs := make([]float64, len(customers))
+	lifetimePaid := make([]float64, len(customers))
+	var capped int
+
+	for i, c := range customers {
+		if c.LifetimePaidCapped {
+			capped++
+		}
+		ids[i] = c.ID
+		emails[i] = c.Email
+		names[i] = c.Name
+		created[i] = c.Created
+		currencies[i] = c.Currency
+		delinquent[i] = c.Delinquent
+		balances[i] = float64(c.Balance) / 100
+		activeSubs[i] = c.ActiveSubscriptions
+		mrrs[i] = float64(c.MRR) / 100
+		lifetimePaid[i] = float64(c.LifetimePaid) / 100
+	}
+
+	frame.Fields = append(frame.Fields,
+		data.NewField("id", nil, ids),
+		data.NewField("email", nil, emails),
+		data.NewField("name", nil, names),
+		data.NewField("created", nil, created),
+		data.NewField("currency", nil, currencies),
+		data.NewField("delinquent", nil, delinquent),
+		data.NewField("balance", nil, balances),
+		data.NewField("active_subscriptions", nil, activeSubs),
+		data.NewField("mrr", nil, mrrs),
+		data.NewField("lifetime_paid", nil, lifetimePaid),
+	)
+
+	if capped > 0 {
+		addNotice(frame, fmt.Sprintf("Lifetime paid counts the newest %d paid invoices per customer; %d customers have more.", stripe.MaxCustomerInvoices, capped))
+	}
+
+	// Searches have no cursor to page with, only the first matches
+	if qm.Search != "" {
+		if hasMore {
+			addNotice(frame, fmt.Sprintf("Showing the first %d matches; raise the limit or narrow the search for more.", frame.Rows()))
+		}
+		if err := sortFrame(frame, qm.SortBy, qm.SortDirection); err != nil {
+			return backend.ErrDataResponse(backend.StatusBadRequest, err.Error())
+		}
+		return backend.DataResponse{Frames: []*data.Frame{frame}}
+	}
+	if err := applyTableOptions(frame, qm, hasMore); err != nil {
+		return backend.ErrDataResponse(backend.StatusBadRequest, err.Error())
+	}
+
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
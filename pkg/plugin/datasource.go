@@ -16,11 +16,15 @@ import (
 var (
 	_ backend.QueryDataHandler      = (*Datasource)(nil)
 	_ backend.CheckHealthHandler    = (*Datasource)(nil)
+	_ backend.StreamHandler         = (*Datasource)(nil)
 	_ instancemgmt.InstanceDisposer = (*Datasource)(nil)
 )
 
 type Datasource struct {
-	client *stripe.Client
+	client   *stripe.Client
+	settings *models.PluginSettings
+	// uid namespaces the Grafana Live channels of this datasource
+	uid string
 }
 
 func NewDatasource(_ context.Context, settings backend.DataSourceInstanceSettings) (instancemgmt.Instance, error) {
@@ -29,12 +33,23 @@ func NewDatasource(_ context.Context, settings backend.DataSourceInstanceSetting
 		return nil, err
 	}
 	return &Datasource{
-		client: stripe.NewClient(config.Secrets.ApiKey),
+		client:   stripe.NewClient(config.Secrets.ApiKey),
+		settings: config,
+		uid:      settings.UID,
 	}, nil
 }
 
 func (d *Datasource) Dispose() {}
 
+// grossMarginPercent returns the configured gross margin, defaulting to 100
+// for datasources built without settings
+func (d *Datasource) grossMarginPercent() float64 {
+	if d.settings == nil {
+		return 100
+	}
+	return d.settings.GrossMarginPercent
+}
+
 func (d *Datasource) QueryData(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
 	response := backend.NewQueryDataResponse()
 	for _, q := range req.Queries {
@@ -57,17 +72,94 @@ const (
 	QueryCharges       QueryType = "charges"
 	QueryProducts      QueryType = "products"
 	// New Stripe dashboard metrics
-	QueryNewMRR       QueryType = "new_mrr"
-	QueryChurnedMRR   QueryType = "churned_mrr"
-	QueryNetNewMRR    QueryType = "net_new_mrr"
-	QueryChurnRate    QueryType = "churn_rate"
-	QueryARPU         QueryType = "arpu"
-	QueryTrialing     QueryType = "trialing"
-	QueryPastDue      QueryType = "past_due"
+	QueryNewMRR               QueryType = "new_mrr"
+	QueryChurnedMRR           QueryType = "churned_mrr"
+	QueryNetNewMRR            QueryType = "net_new_mrr"
+	QueryChurnRate            QueryType = "churn_rate"
+	QueryARPU                 QueryType = "arpu"
+	QueryTrialing             QueryType = "trialing"
+	QueryPastDue              QueryType = "past_due"
+	QueryLogoChurnRate        QueryType = "logo_churn_rate"
+	QueryRevenueChurnRate     QueryType = "revenue_churn_rate"
+	QueryVoluntaryChurnRate   QueryType = "voluntary_churn_rate"
+	QueryInvoluntaryChurnRate QueryType = "involuntary_churn_rate"
+	QueryLTV                  QueryType = "ltv"
+	QueryAvgCustomerLifetime  QueryType = "avg_customer_lifetime"
+	// Revenue retention, trailing 12 months and trailing month
+	QueryNRR12m QueryType = "nrr_12m"
+	QueryNRR1m  QueryType = "nrr_1m"
+	QueryGRR12m QueryType = "grr_12m"
+	QueryGRR1m  QueryType = "grr_1m"
+	// Breakdowns and tables
+	QuerySubscriptionStatus QueryType = "subscription_status"
+	QueryTrialConversion    QueryType = "trial_conversion"
+	QueryCohortRetention    QueryType = "cohort_retention"
+	QueryCustomerLTV        QueryType = "customer_ltv"
+	QueryChurnReasons       QueryType = "churn_reasons"
+	QueryPendingChurn       QueryType = "pending_churn"
+	// customer_list because "customers" is the Total Customers metric
+	QueryCustomerList   QueryType = "customer_list"
+	QueryTopCustomers   QueryType = "top_customers"
+	QueryConcentration  QueryType = "customer_concentration"
+	QueryCustomerDetail QueryType = "customer_detail"
+	QueryCoupons        QueryType = "coupons"
+	QueryQuotes         QueryType = "quotes"
+	QuerySchedules      QueryType = "subscription_schedules"
+	// Revenue time series
+	QueryRecognizedRevenue QueryType = "recognized_revenue"
+	QueryInvoiceLines      QueryType = "invoice_lines"
+	QueryTax               QueryType = "tax"
+	QueryCreditNotes       QueryType = "credit_notes"
+	QueryCheckoutSessions  QueryType = "checkout_sessions"
+	QueryContractedMRR     QueryType = "contracted_mrr"
+	QueryUpcomingRevenue   QueryType = "upcoming_revenue"
+	// Events
+	QueryAnnotations QueryType = "annotations"
+	QueryEvents      QueryType = "events"
+	// Grafana Live streams
+	QueryLiveEvents      QueryType = "live_events"
+	QueryLiveGrossVolume QueryType = "live_gross_volume"
 )
 
 type queryModel struct {
 	QueryType QueryType `json:"queryType"`
+	// GroupBy splits supported metrics by "product", or checkout sessions
+	// by "price" or "payment_link"
+	GroupBy string `json:"groupBy,omitempty"`
+	// ExcludePendingChurn reports MRR and ARR without subscriptions
+	// scheduled to cancel
+	ExcludePendingChurn bool `json:"excludePendingChurn,omitempty"`
+	// Table query options
+	Search        string `json:"search,omitempty"`
+	Limit         int64  `json:"limit,omitempty"`
+	StartingAfter string `json:"startingAfter,omitempty"`
+	SortBy        string `json:"sortBy,omitempty"`
+	SortDirection string `json:"sortDirection,omitempty"` // "asc" or "desc"
+	// RankBy orders top customers by "mrr" (default) or "revenue"
+	RankBy string `json:"rankBy,omitempty"`
+	// CustomerID selects the customer for drill-down queries
+	CustomerID string `json:"customerId,omitempty"`
+	// Interval buckets time series by "day", "week" or "month". Empty
+	// picks one from the panel interval.
+	Interval string `json:"interval,omitempty"`
+	// Preview uses Stripe's upcoming invoice preview for each subscription's
+	// next invoice instead of list prices
+	Preview bool `json:"preview,omitempty"`
+	// EventType filters events by comma separated type patterns such as
+	// "invoice.*"
+	EventType string `json:"eventType,omitempty"`
+	// MinAmount drops events whose object amount is below it, in currency units
+	MinAmount float64 `json:"minAmount,omitempty"`
+	// ObjectID keeps only events about one object, e.g. "sub_..."
+	ObjectID string `json:"objectId,omitempty"`
+}
+
+// listOptions returns the pagination options of a table query
+func (qm queryModel) listOptions() stripe.ListOptions {
+	return stripe.ListOptions{
+		Limit:         qm.Limit,
+		StartingAfter: qm.StartingAfter,
+	}
 }
 
 func (d *Datasource) query(ctx context.Context, q backend.DataQuery) backend.DataResponse {
@@ -78,21 +170,73 @@ func (d *Datasource) query(ctx context.Context, q backend.DataQuery) backend.Dat
 
 	switch qm.QueryType {
 	case QuerySubscriptions:
-		return d.querySubscriptions(ctx, q)
+		return d.querySubscriptions(ctx, q, qm)
 	case QueryInvoices:
-		return d.queryInvoices(ctx, q)
+		return d.queryInvoices(ctx, q, qm)
 	case QueryCharges:
-		return d.queryCharges(ctx, q)
+		return d.queryCharges(ctx, q, qm)
 	case QueryProducts:
 		return d.queryProducts(ctx, q)
 	case QueryRevenue:
-		return d.queryRevenue(ctx, q)
+		return d.queryRevenue(ctx, q, qm)
+	case QuerySubscriptionStatus:
+		return d.querySubscriptionStatus(ctx, q)
+	case QueryTrialConversion:
+		return d.queryTrialConversion(ctx, q)
+	case QueryCohortRetention:
+		return d.queryCohortRetention(ctx, q)
+	case QueryNRR12m, QueryNRR1m, QueryGRR12m, QueryGRR1m:
+		return d.queryRevenueRetention(ctx, q, qm)
+	case QueryCustomerLTV:
+		return d.queryCustomerLTV(ctx, q)
+	case QueryLogoChurnRate, QueryRevenueChurnRate, QueryVoluntaryChurnRate, QueryInvoluntaryChurnRate:
+		return d.queryChurn(ctx, q, qm.QueryType)
+	case QueryChurnReasons:
+		return d.queryChurnReasons(ctx, q)
+	case QueryPendingChurn:
+		return d.queryPendingChurn(ctx, q)
+	case QueryCustomerList:
+		return d.queryCustomerList(ctx, q, qm)
+	case QueryTopCustomers:
+		return d.queryTopCustomers(ctx, q, qm)
+	case QueryConcentration:
+		return d.queryConcentration(ctx, q)
+	case QueryCustomerDetail:
+		return d.queryCustomerDetail(ctx, q, qm)
+	case QueryInvoiceLines:
+		return d.queryInvoiceLines(ctx, q, qm)
+	case QuerySchedules:
+		return d.querySchedules(ctx, q)
+	case QueryEvents:
+		return d.queryEvents(ctx, q, qm)
+	case QueryLiveEvents:
+		return d.queryLiveEvents(ctx, q, qm)
+	case QueryLiveGrossVolume:
+		return d.queryLiveGrossVolume(ctx, q)
+	case QueryAnnotations:
+		return d.queryAnnotations(ctx, q, qm)
+	case QueryUpcomingRevenue:
+		return d.queryUpcomingRevenue(ctx, q, qm)
+	case QueryContractedMRR:
+		return d.queryContractedMRR(ctx, q, qm)
+	case QueryQuotes:
+		return d.queryQuotes(ctx, q, qm)
+	case QueryCoupons:
+		return d.queryCoupons(ctx, q)
+	case QueryCheckoutSessions:
+		return d.queryCheckoutSessions(ctx, q, qm)
+	case QueryCreditNotes:
+		return d.queryCreditNotes(ctx, q, qm)
+	case QueryTax:
+		return d.queryTax(ctx, q, qm)
+	case QueryRecognizedRevenue:
+		return d.queryRecognizedRevenue(ctx, q, qm)
 	default:
-		return d.queryMetrics(ctx, q, qm.QueryType)
+		return d.queryMetrics(ctx, q, qm)
 	}
 }
 
-func (d *Datasource) queryMetrics(ctx context.Context, q backend.DataQuery, queryType QueryType) backend.DataResponse {
+func (d *Datasource) queryMetrics(ctx context.Context, q backend.DataQuery, qm queryModel) backend.DataResponse {
 	metrics, err := d.client.GetMetrics(ctx)
 	if err != nil {
 		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
@@ -104,15 +248,20 @@ func (d *Datasource) queryMetrics(ctx context.Context, q backend.DataQuery, quer
 		PreferredVisualizationPluginID: "stat",
 	}
 
+	mrr := metrics.MRR
+	if qm.ExcludePendingChurn {
+		mrr = metrics.CommittedMRR()
+	}
+
 	var value float64
 	var name string
 
-	switch queryType {
+	switch qm.QueryType {
 	case QueryMRR:
-		value = float64(metrics.MRR) / 100
+		value = float64(mrr) / 100
 		name = "MRR"
 	case QueryARR:
-		value = float64(metrics.ARR) / 100
+		value = float64(mrr*12) / 100
 		name = "ARR"
 	case QuerySubscribers:
 		value = float64(metrics.ActiveSubscribers)
@@ -138,6 +287,12 @@ func (d *Datasource) queryMetrics(ctx context.Context, q backend.DataQuery, quer
 	case QueryARPU:
 		value = float64(metrics.ARPU) / 100
 		name = "ARPU"
+	case QueryLTV:
+		value = float64(metrics.LTV(d.grossMarginPercent())) / 100
+		name = "LTV"
+	case QueryAvgCustomerLifetime:
+		value = metrics.AvgCustomerLifetime()
+		name = "Avg Customer Lifetime (months)"
 	case QueryTrialing:
 		value = float64(metrics.TrialingCount)
 		name = "Trialing"
@@ -145,7 +300,7 @@ func (d *Datasource) queryMetrics(ctx context.Context, q backend.DataQuery, quer
 		value = float64(metrics.PastDueCount)
 		name = "Past Due"
 	default:
-		return backend.ErrDataResponse(backend.StatusBadRequest, fmt.Sprintf("unknown query type: %s", queryType))
+		return backend.ErrDataResponse(backend.StatusBadRequest, fmt.Sprintf("unknown query type: %s", qm.QueryType))
 	}
 
 	frame.Fields = append(frame.Fields,
@@ -156,12 +311,22 @@ func (d *Datasource) queryMetrics(ctx context.Context, q backend.DataQuery, quer
 	return backend.DataResponse{Frames: []*data.Frame{frame}}
 }
 
-func (d *Datasource) querySubscriptions(ctx context.Context, q backend.DataQuery) backend.DataResponse {
-	subs, err := d.client.GetSubscriptions(ctx)
+func (d *Datasource) querySubscriptions(ctx context.Context, q backend.DataQuery, qm queryModel) backend.DataResponse {
+	subs, hasMore, err := d.client.GetSubscriptions(ctx, qm.listOptions())
 	if err != nil {
 		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
 	}
 
+	frame := subscriptionsFrame(subs)
+	if err := applyTableOptions(frame, qm, hasMore); err != nil {
+		return backend.ErrDataResponse(backend.StatusBadRequest, err.Error())
+	}
+
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
+
+// subscriptionsFrame builds the subscriptions table frame
+func subscriptionsFrame(subs []stripe.SubscriptionData) *data.Frame {
 	frame := data.NewFrame("subscriptions")
 	frame.Meta = &data.FrameMeta{
 		PreferredVisualization: data.VisTypeTable,
@@ -195,15 +360,25 @@ func (d *Datasource) querySubscriptions(ctx context.Context, q backend.DataQuery
 		data.NewField("created", nil, created),
 	)
 
-	return backend.DataResponse{Frames: []*data.Frame{frame}}
+	return frame
 }
 
-func (d *Datasource) queryInvoices(ctx context.Context, q backend.DataQuery) backend.DataResponse {
-	invoices, err := d.client.GetInvoices(ctx)
+func (d *Datasource) queryInvoices(ctx context.Context, q backend.DataQuery, qm queryModel) backend.DataResponse {
+	invoices, hasMore, err := d.client.GetInvoices(ctx, qm.listOptions())
 	if err != nil {
 		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
 	}
 
+	frame := invoicesFrame(invoices)
+	if err := applyTableOptions(frame, qm, hasMore); err != nil {
+		return backend.ErrDataResponse(backend.StatusBadRequest, err.Error())
+	}
+
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
+
+// invoicesFrame builds the invoices table frame
+func invoicesFrame(invoices []stripe.InvoiceData) *data.Frame {
 	frame := data.NewFrame("invoices")
 	frame.Meta = &data.FrameMeta{
 		PreferredVisualization: data.VisTypeTable,
@@ -211,18 +386,22 @@ func (d *Datasource) queryInvoices(ctx context.Context, q backend.DataQuery) bac
 
 	ids := make([]string, len(invoices))
 	customers := make([]string, len(invoices))
+	products := make([]string, len(invoices))
 	statuses := make([]string, len(invoices))
 	amounts := make([]float64, len(invoices))
 	amountsPaid := make([]float64, len(invoices))
+	taxes := make([]float64, len(invoices))
 	created := make([]time.Time, len(invoices))
 	paid := make([]bool, len(invoices))
 
 	for i, inv := range invoices {
 		ids[i] = inv.ID
 		customers[i] = inv.Customer
+		products[i] = inv.ProductName
 		statuses[i] = inv.Status
 		amounts[i] = float64(inv.Amount) / 100
 		amountsPaid[i] = float64(inv.AmountPaid) / 100
+		taxes[i] = float64(inv.Tax) / 100
 		created[i] = inv.Created
 		paid[i] = inv.Paid
 	}
@@ -230,22 +409,34 @@ func (d *Datasource) queryInvoices(ctx context.Context, q backend.DataQuery) bac
 	frame.Fields = append(frame.Fields,
 		data.NewField("id", nil, ids),
 		data.NewField("customer", nil, customers),
+		data.NewField("product", nil, products),
 		data.NewField("status", nil, statuses),
 		data.NewField("amount", nil, amounts),
 		data.NewField("amount_paid", nil, amountsPaid),
+		data.NewField("tax", nil, taxes),
 		data.NewField("created", nil, created),
 		data.NewField("paid", nil, paid),
 	)
 
-	return backend.DataResponse{Frames: []*data.Frame{frame}}
+	return frame
 }
 
-func (d *Datasource) queryCharges(ctx context.Context, q backend.DataQuery) backend.DataResponse {
-	charges, err := d.client.GetCharges(ctx)
+func (d *Datasource) queryCharges(ctx context.Context, q backend.DataQuery, qm queryModel) backend.DataResponse {
+	charges, hasMore, err := d.client.GetCharges(ctx, qm.listOptions())
 	if err != nil {
 		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
 	}
 
+	frame := chargesFrame(charges)
+	if err := applyTableOptions(frame, qm, hasMore); err != nil {
+		return backend.ErrDataResponse(backend.StatusBadRequest, err.Error())
+	}
+
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
+
+// chargesFrame builds the charges table frame
+func chargesFrame(charges []stripe.ChargeData) *data.Frame {
 	frame := data.NewFrame("charges")
 	frame.Meta = &data.FrameMeta{
 		PreferredVisualization: data.VisTypeTable,
@@ -279,7 +470,7 @@ func (d *Datasource) queryCharges(ctx context.Context, q backend.DataQuery) back
 		data.NewField("refunded", nil, refunded),
 	)
 
-	return backend.DataResponse{Frames: []*data.Frame{frame}}
+	return frame
 }
 
 func (d *Datasource) queryProducts(ctx context.Context, q backend.DataQuery) backend.DataResponse {
@@ -312,28 +503,12 @@ func (d *Datasource) queryProducts(ctx context.Context, q backend.DataQuery) bac
 	return backend.DataResponse{Frames: []*data.Frame{frame}}
 }
 
-func (d *Datasource) queryRevenue(ctx context.Context, q backend.DataQuery) backend.DataResponse {
-	metrics, err := d.client.GetInvoiceMetrics(ctx)
-	if err != nil {
-		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
-	}
-
-	now := time.Now()
-	frame := data.NewFrame("revenue")
-	frame.Fields = append(frame.Fields,
-		data.NewField("time", nil, []time.Time{now}),
-		data.NewField("Total Revenue", nil, []float64{float64(metrics.TotalRevenue) / 100}),
-	)
-
-	return backend.DataResponse{Frames: []*data.Frame{frame}}
-}
-
 func (d *Datasource) CheckHealth(ctx context.Context, req *backend.CheckHealthRequest) (*backend.CheckHealthResult, error) {
 	config, err := models.LoadPluginSettings(*req.PluginContext.DataSourceInstanceSettings)
 	if err != nil {
 		return &backend.CheckHealthResult{
 			Status:  backend.HealthStatusError,
-			Message: "Unable to load settings",
+			Message: fmt.Sprintf("Unable to load settings: %v", err),
 		}, nil
 	}
 
@@ -0,0 +1,80 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/jfreels123/stripe-datasource/pkg/stripe"
+)
+
+// queryEvents returns raw Stripe events in the panel time range
+func (d *Datasource) queryEvents(ctx context.Context, q backend.DataQuery, qm queryModel) backend.DataResponse {
+	events, hasMore, err := d.client.GetEvents(ctx, qm.eventFilter(q))
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	frame := eventsFrame(events)
+	if err := applyTableOptions(frame, qm, hasMore); err != nil {
+		return backend.ErrDataResponse(backend.StatusBadRequest, err.Error())
+	}
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
+
+// eventsFrame builds the events table frame
+func eventsFrame(events []stripe.EventData) *data.Frame {
+	frame := data.NewFrame("events")
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualization: data.VisTypeTable,
+	}
+
+	n := len(events)
+	ids := make([]string, n)
+	types := make([]string, n)
+	created := make([]time.Time, n)
+	objectIDs := make([]string, n)
+	objectTypes := make([]string, n)
+	versions := make([]string, n)
+	payloads := make([]json.RawMessage, n)
+
+	for i, e := range events {
+		ids[i] = e.ID
+		types[i] = e.Type
+		created[i] = e.Created
+		objectIDs[i] = e.ObjectID
+		objectTypes[i] = e.ObjectType
+		versions[i] = e.APIVersion
+		payloads[i] = e.Payload
+		if payloads[i] == nil {
+			payloads[i] = json.RawMessage("null")
+		}
+	}
+
+	frame.Fields = append(frame.Fields,
+		data.NewField("id", nil, ids),
+		data.NewField("type", nil, types),
+		data.NewField("created", nil, created),
+		data.NewField("object_id", nil, objectIDs),
+		data.NewField("object_type", nil, objectTypes),
+		data.NewField("api_version", nil, versions),
+		data.NewField("payload", nil, payloads),
+	)
+
+	return frame
+}
+
+// eventFilter builds the event filter for the panel range and query options
+func (qm queryModel) eventFilter(q backend.DataQuery) stripe.EventFilter {
+	return stripe.EventFilter{
+		ListOptions: qm.listOptions(),
+		Types:       stripe.ParseEventTypes(qm.EventType),
+		ObjectID:    qm.ObjectID,
+		From:        q.TimeRange.From,
+		To:          q.TimeRange.To,
+		MinAmount:   qm.MinAmount,
+	}
+}
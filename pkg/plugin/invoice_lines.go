@@ -0,0 +1,93 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/jfreels123/stripe-datasource/pkg/stripe"
+)
+
+// queryInvoiceLines returns one row per line of the invoices created in the
+// panel time range
+func (d *Datasource) queryInvoiceLines(ctx context.Context, q backend.DataQuery, qm queryModel) backend.DataResponse {
+	lines, hasMore, err := d.client.GetInvoiceLines(ctx, q.TimeRange.From, q.TimeRange.To, qm.listOptions())
+	if errors.Is(err, stripe.ErrLineNotFound) {
+		return backend.ErrDataResponse(backend.StatusBadRequest, fmt.Sprintf("starting after: %v", err))
+	}
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	frame := data.NewFrame("invoice_lines")
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualization: data.VisTypeTable,
+	}
+
+	n := len(lines)
+	ids := make([]string, n)
+	created := make([]time.Time, n)
+	invoices := make([]string, n)
+	customers := make([]string, n)
+	statuses := make([]string, n)
+	productIDs := make([]string, n)
+	products := make([]string, n)
+	prices := make([]string, n)
+	descriptions := make([]string, n)
+	quantities := make([]int64, n)
+	amounts := make([]float64, n)
+	discounts := make([]float64, n)
+	taxes := make([]float64, n)
+	currencies := make([]string, n)
+	periodStarts := make([]time.Time, n)
+	periodEnds := make([]time.Time, n)
+	prorations := make([]bool, n)
+
+	for i, l := range lines {
+		ids[i] = l.ID
+		created[i] = l.Created
+		invoices[i] = l.Invoice
+		customers[i] = l.Customer
+		statuses[i] = l.Status
+		productIDs[i] = l.ProductID
+		products[i] = l.ProductName
+		prices[i] = l.PriceID
+		descriptions[i] = l.Description
+		quantities[i] = l.Quantity
+		amounts[i] = float64(l.Amount) / 100
+		discounts[i] = float64(l.DiscountAmount) / 100
+		taxes[i] = float64(l.Tax) / 100
+		currencies[i] = l.Currency
+		periodStarts[i] = l.PeriodStart
+		periodEnds[i] = l.PeriodEnd
+		prorations[i] = l.Proration
+	}
+
+	frame.Fields = append(frame.Fields,
+		data.NewField("id", nil, ids),
+		data.NewField("time", nil, created),
+		data.NewField("invoice", nil, invoices),
+		data.NewField("customer", nil, customers),
+		data.NewField("status", nil, statuses),
+		data.NewField("product_id", nil, productIDs),
+		data.NewField("product", nil, products),
+		data.NewField("price", nil, prices),
+		data.NewField("description", nil, descriptions),
+		data.NewField("quantity", nil, quantities),
+		data.NewField("amount", nil, amounts),
+		data.NewField("discount_amount", nil, discounts),
+		data.NewField("tax", nil, taxes),
+		data.NewField("currency", nil, currencies),
+		data.NewField("period_start", nil, periodStarts),
+		data.NewField("period_end", nil, periodEnds),
+		data.NewField("proration", nil, prorations),
+	)
+
+	if err := applyTableOptions(frame, qm, hasMore); err != nil {
+		return backend.ErrDataResponse(backend.StatusBadRequest, err.Error())
+	}
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
@@ -0,0 +1,52 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+func (d *Datasource) queryCustomerLTV(ctx context.Context, q backend.DataQuery) backend.DataResponse {
+	customers, err := d.client.GetCustomerLTV(ctx)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	frame := data.NewFrame("customer_ltv")
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualization: data.VisTypeTable,
+	}
+
+	ids := make([]string, len(customers))
+	emails := make([]string, len(customers))
+	currencies := make([]string, len(customers))
+	invoices := make([]int64, len(customers))
+	values := make([]float64, len(customers))
+	firstPaid := make([]time.Time, len(customers))
+	lastPaid := make([]time.Time, len(customers))
+
+	for i, c := range customers {
+		ids[i] = c.Customer
+		emails[i] = c.Email
+		currencies[i] = c.Currency
+		invoices[i] = c.PaidInvoices
+		values[i] = float64(c.LifetimeValue) / 100
+		firstPaid[i] = c.FirstPaid
+		lastPaid[i] = c.LastPaid
+	}
+
+	frame.Fields = append(frame.Fields,
+		data.NewField("customer", nil, ids),
+		data.NewField("email", nil, emails),
+		data.NewField("currency", nil, currencies),
+		data.NewField("paid_invoices", nil, invoices),
+		data.NewField("lifetime_value", nil, values),
+		data.NewField("first_paid", nil, firstPaid),
+		data.NewField("last_paid", nil, lastPaid),
+	)
+
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
@@ -0,0 +1,95 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/jfreels123/stripe-datasource/pkg/stripe"
+)
+
+// queryQuotes returns the quotes created in the panel time range and a frame
+// of pipeline totals for them. The limit cuts the quotes frame only; the
+// totals cover every quote in the range.
+func (d *Datasource) queryQuotes(ctx context.Context, q backend.DataQuery, qm queryModel) backend.DataResponse {
+	pipeline, err := d.client.GetQuotePipeline(ctx, q.TimeRange.From, q.TimeRange.To)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	totals := data.NewFrame("quote_pipeline")
+	totals.Meta = &data.FrameMeta{
+		PreferredVisualizationPluginID: "stat",
+	}
+	totals.Fields = append(totals.Fields,
+		data.NewField("time", nil, []time.Time{q.TimeRange.To}),
+		data.NewField("Open Quotes", nil, []int64{pipeline.Open}),
+		data.NewField("Open Quote Value", nil, []float64{float64(pipeline.OpenValue) / 100}),
+		data.NewField("Accepted Quotes", nil, []int64{pipeline.Accepted}),
+		data.NewField("Accepted Value", nil, []float64{float64(pipeline.AcceptedValue) / 100}),
+		data.NewField("Acceptance Rate %", nil, []float64{pipeline.AcceptanceRate}),
+		data.NewField("Avg Days to Accept", nil, []float64{pipeline.AvgDaysToAccept}),
+	)
+
+	quotes := pipeline.Quotes
+	truncated := qm.Limit > 0 && int64(len(quotes)) > qm.Limit
+	if truncated {
+		quotes = quotes[:qm.Limit]
+	}
+	frame := quotesFrame(quotes)
+	if truncated {
+		addNotice(frame, fmt.Sprintf("Showing the newest %d of %d quotes; raise the limit for more.", len(quotes), len(pipeline.Quotes)))
+	}
+	// Quotes are listed in full, so there is no next page to point to
+	if err := applyTableOptions(frame, qm, false); err != nil {
+		return backend.ErrDataResponse(backend.StatusBadRequest, err.Error())
+	}
+
+	return backend.DataResponse{Frames: []*data.Frame{frame, totals}}
+}
+
+// quotesFrame builds the quotes table frame, one row per quote
+func quotesFrame(quotes []stripe.QuoteData) *data.Frame {
+	frame := data.NewFrame("quotes")
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualization: data.VisTypeTable,
+	}
+
+	n := len(quotes)
+	ids := make([]string, n)
+	numbers := make([]string, n)
+	customers := make([]string, n)
+	statuses := make([]string, n)
+	amounts := make([]float64, n)
+	currencies := make([]string, n)
+	created := make([]time.Time, n)
+	expires := make([]*time.Time, n)
+	accepted := make([]*time.Time, n)
+
+	for i, qd := range quotes {
+		ids[i] = qd.ID
+		numbers[i] = qd.Number
+		customers[i] = qd.Customer
+		statuses[i] = qd.Status
+		amounts[i] = float64(qd.Amount) / 100
+		currencies[i] = qd.Currency
+		created[i] = qd.Created
+		expires[i] = optionalTime(qd.ExpiresAt)
+		accepted[i] = optionalTime(qd.AcceptedAt)
+	}
+
+	frame.Fields = append(frame.Fields,
+		data.NewField("id", nil, ids),
+		data.NewField("number", nil, numbers),
+		data.NewField("customer", nil, customers),
+		data.NewField("status", nil, statuses),
+		data.NewField("amount", nil, amounts),
+		data.NewField("currency", nil, currencies),
+		data.NewField("created", nil, created),
+		data.NewField("expires_at", nil, expires),
+		data.NewField("accepted_at", nil, accepted),
+	)
+	return frame
+}
@@ -0,0 +1,40 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// queryRecognizedRevenue returns revenue earned per bucket over the panel
+// range and the deferred revenue balance at the end of each bucket
+func (d *Datasource) queryRecognizedRevenue(ctx context.Context, q backend.DataQuery, qm queryModel) backend.DataResponse {
+	interval, err := bucketInterval(qm, q)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusBadRequest, err.Error())
+	}
+
+	points, err := d.client.GetRecognizedRevenue(ctx, q.TimeRange.From, q.TimeRange.To, interval)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	times := make([]time.Time, len(points))
+	recognized := make([]float64, len(points))
+	deferred := make([]float64, len(points))
+	for i, p := range points {
+		times[i] = p.Time
+		recognized[i] = float64(p.Recognized) / 100
+		deferred[i] = float64(p.Deferred) / 100
+	}
+
+	frame := data.NewFrame("recognized_revenue",
+		data.NewField("time", nil, times),
+		data.NewField("Recognized Revenue", nil, recognized),
+		data.NewField("Deferred Revenue", nil, deferred),
+	)
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
@@ -0,0 +1,55 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+func (d *Datasource) queryRevenueRetention(ctx context.Context, q backend.DataQuery, qm queryModel) backend.DataResponse {
+	months := 12
+	if qm.QueryType == QueryNRR1m || qm.QueryType == QueryGRR1m {
+		months = 1
+	}
+	byProduct := qm.GroupBy == "product"
+
+	retention, err := d.client.GetRevenueRetention(ctx, q.TimeRange.To, months, byProduct)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	name := "NRR %"
+	if qm.QueryType == QueryGRR12m || qm.QueryType == QueryGRR1m {
+		name = "GRR %"
+	}
+
+	now := time.Now()
+	if q.TimeRange.To.Before(now) {
+		now = q.TimeRange.To
+	}
+	frame := data.NewFrame("metrics")
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualizationPluginID: "stat",
+	}
+	frame.Fields = append(frame.Fields, data.NewField("time", nil, []time.Time{now}))
+
+	if !byProduct && len(retention) == 0 {
+		frame.Fields = append(frame.Fields, data.NewField(name, nil, []float64{0}))
+	}
+	for _, r := range retention {
+		value := r.NRR
+		if name == "GRR %" {
+			value = r.GRR
+		}
+		var labels data.Labels
+		if byProduct {
+			labels = data.Labels{"product": r.Group}
+		}
+		frame.Fields = append(frame.Fields, data.NewField(name, labels, []float64{value}))
+	}
+
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
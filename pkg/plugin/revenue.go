@@ -0,0 +1,76 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/jfreels123/stripe-datasource/pkg/stripe"
+)
+
+// queryRevenue returns paid, refunded, credited and net revenue bucketed over
+// the panel range, with and without tax
+func (d *Datasource) queryRevenue(ctx context.Context, q backend.DataQuery, qm queryModel) backend.DataResponse {
+	interval, err := bucketInterval(qm, q)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusBadRequest, err.Error())
+	}
+
+	points, err := d.client.GetRevenueSeries(ctx, q.TimeRange.From, q.TimeRange.To, interval)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	times := make([]time.Time, len(points))
+	paid := make([]float64, len(points))
+	refunded := make([]float64, len(points))
+	credited := make([]float64, len(points))
+	net := make([]float64, len(points))
+	tax := make([]float64, len(points))
+	paidExTax := make([]float64, len(points))
+	netExTax := make([]float64, len(points))
+	for i, p := range points {
+		times[i] = p.Time
+		paid[i] = float64(p.Paid) / 100
+		refunded[i] = float64(p.Refunded) / 100
+		credited[i] = float64(p.Credited) / 100
+		net[i] = float64(p.Net) / 100
+		tax[i] = float64(p.Tax) / 100
+		paidExTax[i] = float64(p.Paid-p.Tax) / 100
+		netExTax[i] = float64(p.NetExcludingTax()) / 100
+	}
+
+	frame := data.NewFrame("revenue",
+		data.NewField("time", nil, times),
+		data.NewField("Paid", nil, paid),
+		data.NewField("Refunded", nil, refunded),
+		data.NewField("Credited", nil, credited),
+		data.NewField("Net Revenue", nil, net),
+		data.NewField("Tax", nil, tax),
+		data.NewField("Paid excl. Tax", nil, paidExTax),
+		data.NewField("Net Revenue excl. Tax", nil, netExTax),
+	)
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
+
+// bucketInterval returns the interval chosen in the query editor, or the
+// closest of day, week and month to the panel interval
+func bucketInterval(qm queryModel, q backend.DataQuery) (string, error) {
+	switch qm.Interval {
+	case stripe.IntervalDay, stripe.IntervalWeek, stripe.IntervalMonth:
+		return qm.Interval, nil
+	case "":
+	default:
+		return "", fmt.Errorf("unknown interval %q", qm.Interval)
+	}
+
+	switch {
+	case q.Interval >= 28*24*time.Hour:
+		return stripe.IntervalMonth, nil
+	case q.Interval >= 7*24*time.Hour:
+		return stripe.IntervalWeek, nil
+	}
+	return stripe.IntervalDay, nil
+}
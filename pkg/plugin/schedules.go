@@ -0,0 +1,95 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// querySchedules returns one row per phase of every open subscription schedule
+func (d *Datasource) querySchedules(ctx context.Context, q backend.DataQuery) backend.DataResponse {
+	phases, err := d.client.GetSubscriptionSchedules(ctx)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	frame := data.NewFrame("subscription_schedules")
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualization: data.VisTypeTable,
+	}
+
+	n := len(phases)
+	schedules := make([]string, n)
+	customers := make([]string, n)
+	subscriptions := make([]string, n)
+	statuses := make([]string, n)
+	endBehaviors := make([]string, n)
+	numbers := make([]int64, n)
+	current := make([]bool, n)
+	starts := make([]time.Time, n)
+	ends := make([]*time.Time, n)
+	trialEnds := make([]*time.Time, n)
+	mrr := make([]float64, n)
+
+	for i, p := range phases {
+		schedules[i] = p.Schedule
+		customers[i] = p.Customer
+		subscriptions[i] = p.Subscription
+		statuses[i] = p.Status
+		endBehaviors[i] = p.EndBehavior
+		numbers[i] = p.Phase
+		current[i] = p.Current
+		starts[i] = p.Start
+		ends[i] = optionalTime(p.End)
+		trialEnds[i] = optionalTime(p.TrialEnd)
+		mrr[i] = float64(p.MRR) / 100
+	}
+
+	frame.Fields = append(frame.Fields,
+		data.NewField("schedule", nil, schedules),
+		data.NewField("customer", nil, customers),
+		data.NewField("subscription", nil, subscriptions),
+		data.NewField("status", nil, statuses),
+		data.NewField("end_behavior", nil, endBehaviors),
+		data.NewField("phase", nil, numbers),
+		data.NewField("current", nil, current),
+		data.NewField("start", nil, starts),
+		data.NewField("end", nil, ends),
+		data.NewField("trial_end", nil, trialEnds),
+		data.NewField("mrr", nil, mrr),
+	)
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
+
+// queryContractedMRR returns projected MRR per bucket from now to the end of
+// the panel range
+func (d *Datasource) queryContractedMRR(ctx context.Context, q backend.DataQuery, qm queryModel) backend.DataResponse {
+	if !q.TimeRange.To.After(time.Now()) {
+		return backend.ErrDataResponse(backend.StatusBadRequest, "contracted MRR needs a time range ending in the future, e.g. now to now+6M")
+	}
+	interval, err := bucketInterval(qm, q)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusBadRequest, err.Error())
+	}
+
+	points, err := d.client.GetContractedMRR(ctx, q.TimeRange.From, q.TimeRange.To, interval)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	times := make([]time.Time, len(points))
+	mrr := make([]float64, len(points))
+	for i, p := range points {
+		times[i] = p.Time
+		mrr[i] = float64(p.MRR) / 100
+	}
+
+	frame := data.NewFrame("contracted_mrr",
+		data.NewField("time", nil, times),
+		data.NewField("Contracted MRR", nil, mrr),
+	)
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
@@ -0,0 +1,46 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+func (d *Datasource) querySubscriptionStatus(ctx context.Context, q backend.DataQuery) backend.DataResponse {
+	statuses, err := d.client.GetSubscriptionStatusBreakdown(ctx)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	// Every row shares the same timestamp so the frame can be stored as a
+	// snapshot and charted alongside earlier ones
+	now := time.Now()
+	frame := data.NewFrame("subscription_status")
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualizationPluginID: "piechart",
+	}
+
+	times := make([]time.Time, len(statuses))
+	names := make([]string, len(statuses))
+	counts := make([]int64, len(statuses))
+	mrrs := make([]float64, len(statuses))
+
+	for i, s := range statuses {
+		times[i] = now
+		names[i] = s.Status
+		counts[i] = s.Count
+		mrrs[i] = float64(s.MRR) / 100
+	}
+
+	frame.Fields = append(frame.Fields,
+		data.NewField("time", nil, times),
+		data.NewField("status", nil, names),
+		data.NewField("subscriptions", nil, counts),
+		data.NewField("mrr", nil, mrrs),
+	)
+
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
@@ -0,0 +1,299 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/grafana/grafana-plugin-sdk-go/live"
+	"github.com/jfreels123/stripe-datasource/pkg/stripe"
+)
+
+const (
+	// streamPollInterval is how often running streams poll the Events API
+	streamPollInterval = 10 * time.Second
+	// streamPageSize is the number of events fetched per request; a poll
+	// pages back until it reaches the cursor
+	streamPageSize = 100
+	// streamOverlap is how far before the newest event sent each poll looks
+	// again, to catch events Stripe makes visible late
+	streamOverlap = time.Minute
+	// Channel paths: "events" streams every event, "events/<type>" one
+	// event type, and "gross_volume" today's running gross volume
+	liveEventsPath      = "events"
+	liveGrossVolumePath = "gross_volume"
+)
+
+// queryLiveEvents returns an empty events frame bound to the channel that
+// streams new events of the query's event type
+func (d *Datasource) queryLiveEvents(_ context.Context, _ backend.DataQuery, qm queryModel) backend.DataResponse {
+	eventType := strings.TrimSpace(qm.EventType)
+	if strings.ContainsAny(eventType, "*,") {
+		return backend.ErrDataResponse(backend.StatusBadRequest, "live events take a single event type without wildcards, e.g. charge.succeeded")
+	}
+	path := liveEventsPath
+	if eventType != "" {
+		path += "/" + eventType
+	}
+
+	channel, err := d.channel(path)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusBadRequest, fmt.Sprintf("event type %q: %v", eventType, err))
+	}
+	frame := eventsFrame(nil)
+	frame.Meta.Channel = channel
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
+
+// queryLiveGrossVolume returns an empty gross volume frame bound to the
+// channel that streams today's running gross volume
+func (d *Datasource) queryLiveGrossVolume(_ context.Context, _ backend.DataQuery) backend.DataResponse {
+	channel, err := d.channel(liveGrossVolumePath)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, err.Error())
+	}
+	frame := grossVolumeFrame(nil, nil)
+	frame.Meta.Channel = channel
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
+
+// channel returns the Grafana Live channel ID of a path on this datasource
+func (d *Datasource) channel(path string) (string, error) {
+	ch := live.Channel{
+		Scope:     live.ScopeDatasource,
+		Namespace: d.uid,
+		Path:      path,
+	}
+	if !ch.IsValid() {
+		return "", fmt.Errorf("invalid live channel %q", ch.String())
+	}
+	return ch.String(), nil
+}
+
+// grossVolumeFrame builds the gross volume frame, one row per update
+func grossVolumeFrame(times []time.Time, volumes []stripe.GrossVolume) *data.Frame {
+	amounts := make([]float64, len(volumes))
+	payments := make([]int64, len(volumes))
+	for i, v := range volumes {
+		amounts[i] = float64(v.Amount) / 100
+		payments[i] = v.Payments
+	}
+	if times == nil {
+		times = []time.Time{}
+	}
+
+	frame := data.NewFrame("gross_volume",
+		data.NewField("time", nil, times),
+		data.NewField("Gross Volume", nil, amounts),
+		data.NewField("Payments", nil, payments),
+	)
+	frame.Meta = &data.FrameMeta{}
+	return frame
+}
+
+// streamEventTypes returns the event types streamed on an events channel
+// path: none, meaning every event, for "events" and one for "events/<type>"
+func streamEventTypes(path string) ([]string, bool) {
+	if path == liveEventsPath {
+		return nil, true
+	}
+	eventType, ok := strings.CutPrefix(path, liveEventsPath+"/")
+	if !ok || eventType == "" || strings.Contains(eventType, "/") {
+		return nil, false
+	}
+	return []string{eventType}, true
+}
+
+// SubscribeStream allows subscribing to the event and gross volume channels
+func (d *Datasource) SubscribeStream(_ context.Context, req *backend.SubscribeStreamRequest) (*backend.SubscribeStreamResponse, error) {
+	if _, ok := streamEventTypes(req.Path); !ok && req.Path != liveGrossVolumePath {
+		return &backend.SubscribeStreamResponse{Status: backend.SubscribeStreamStatusNotFound}, nil
+	}
+	return &backend.SubscribeStreamResponse{Status: backend.SubscribeStreamStatusOK}, nil
+}
+
+// PublishStream rejects publications, channels are only fed from Stripe
+func (d *Datasource) PublishStream(_ context.Context, _ *backend.PublishStreamRequest) (*backend.PublishStreamResponse, error) {
+	return &backend.PublishStreamResponse{Status: backend.PublishStreamStatusPermissionDenied}, nil
+}
+
+// RunStream polls the Events API while a channel has subscribers
+func (d *Datasource) RunStream(ctx context.Context, req *backend.RunStreamRequest, sender *backend.StreamSender) error {
+	if req.Path == liveGrossVolumePath {
+		return d.runGrossVolumeStream(ctx, sender)
+	}
+	types, ok := streamEventTypes(req.Path)
+	if !ok {
+		return fmt.Errorf("unknown stream path: %s", req.Path)
+	}
+
+	cursor := newEventCursor(time.Now())
+	return everyPoll(ctx, func(now time.Time) error {
+		events, err := d.newEvents(ctx, cursor, types, now)
+		if err != nil {
+			// Keep the stream open and retry on the next poll
+			backend.Logger.Warn("Polling Stripe events failed", "path", req.Path, "error", err)
+			return nil
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		return sender.SendFrame(eventsFrame(events), data.IncludeAll)
+	})
+}
+
+// runGrossVolumeStream sends today's gross volume, then adds each succeeded
+// charge as its event arrives. Days start at midnight UTC.
+func (d *Datasource) runGrossVolumeStream(ctx context.Context, sender *backend.StreamSender) error {
+	// Start the cursor before listing so that charges made meanwhile come
+	// through events; the tally skips those already listed
+	now := time.Now()
+	cursor := newEventCursor(now)
+	tally := &grossVolumeTally{day: startOfDay(now)}
+	volume, err := d.client.GetGrossVolume(ctx, tally.day)
+	if err != nil {
+		return fmt.Errorf("stripe error: %w", err)
+	}
+	tally.volume = volume
+
+	send := func(now time.Time) error {
+		frame := grossVolumeFrame([]time.Time{now}, []stripe.GrossVolume{tally.volume})
+		return sender.SendFrame(frame, data.IncludeAll)
+	}
+	if err := send(now); err != nil {
+		return err
+	}
+
+	return everyPoll(ctx, func(now time.Time) error {
+		events, err := d.newEvents(ctx, cursor, []string{"charge.succeeded"}, now)
+		if err != nil {
+			backend.Logger.Warn("Polling Stripe events failed", "path", liveGrossVolumePath, "error", err)
+			return nil
+		}
+		tally.apply(now, events)
+		return send(now)
+	})
+}
+
+// grossVolumeTally keeps today's gross volume up to date from
+// charge.succeeded events
+type grossVolumeTally struct {
+	day    time.Time
+	volume stripe.GrossVolume
+}
+
+// apply starts a new day when now has passed midnight UTC, then counts the
+// charges of today's events
+func (t *grossVolumeTally) apply(now time.Time, events []stripe.EventData) {
+	if today := startOfDay(now); today.After(t.day) {
+		t.day = today
+		t.volume = stripe.GrossVolume{}
+	}
+	for _, e := range events {
+		if !e.Created.Before(t.day) && e.HasAmount {
+			t.volume.Add(e.ObjectID, e.Amount, e.Currency)
+		}
+	}
+}
+
+// startOfDay returns midnight UTC of the day of t. UTC days are whole
+// multiples of 24 hours since the zero time.
+func startOfDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// everyPoll calls poll every streamPollInterval until the stream ends or
+// poll fails
+func everyPoll(ctx context.Context, poll func(now time.Time) error) error {
+	ticker := time.NewTicker(streamPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			if err := poll(now); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// newEvents returns the events of the given types created up to now that
+// were not sent yet, oldest first. It pages back to the cursor's overlap
+// window, so a burst of events is never cut short.
+func (d *Datasource) newEvents(ctx context.Context, cursor *eventCursor, types []string, now time.Time) ([]stripe.EventData, error) {
+	filter := stripe.EventFilter{
+		ListOptions: stripe.ListOptions{Limit: streamPageSize},
+		Types:       types,
+		From:        cursor.from(),
+		To:          now,
+	}
+	var events []stripe.EventData
+	for {
+		page, hasMore, err := d.client.GetEvents(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, page...)
+		if !hasMore || len(page) == 0 {
+			break
+		}
+		filter.StartingAfter = page[len(page)-1].ID
+	}
+	return cursor.advance(events), nil
+}
+
+// eventCursor tracks the newest event sent on a stream. Each poll looks back
+// streamOverlap before it, and the events sent in that window are remembered
+// to avoid sending them twice. Events created before the stream started are
+// never sent.
+type eventCursor struct {
+	start time.Time
+	since time.Time
+	seen  map[string]time.Time
+}
+
+func newEventCursor(start time.Time) *eventCursor {
+	start = start.Truncate(time.Second)
+	return &eventCursor{
+		start: start,
+		since: start,
+		seen:  map[string]time.Time{},
+	}
+}
+
+// from returns the start of the window the next poll lists
+func (c *eventCursor) from() time.Time {
+	return c.since.Add(-streamOverlap)
+}
+
+// advance returns the events not sent yet, oldest first, and moves the
+// cursor to the newest of them. Events are listed newest first.
+func (c *eventCursor) advance(events []stripe.EventData) []stripe.EventData {
+	var fresh []stripe.EventData
+	for i := len(events) - 1; i >= 0; i-- {
+		e := events[i]
+		if e.Created.Before(c.start) {
+			continue
+		}
+		if _, ok := c.seen[e.ID]; ok {
+			continue
+		}
+		if e.Created.After(c.since) {
+			c.since = e.Created
+		}
+		c.seen[e.ID] = e.Created
+		fresh = append(fresh, e)
+	}
+	// Forget events the next poll no longer lists
+	for id, created := range c.seen {
+		if created.Before(c.from()) {
+			delete(c.seen, id)
+		}
+	}
+	return fresh
+}
@@ -0,0 +1,106 @@
+package plugin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jfreels123/stripe-datasource/pkg/stripe"
+)
+
+func TestEventCursorAdvance(t *testing.T) {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	cursor := newEventCursor(start.Add(500 * time.Millisecond))
+	event := func(id string, secs int) stripe.EventData {
+		return stripe.EventData{ID: id, Created: start.Add(time.Duration(secs) * time.Second)}
+	}
+
+	// Listed newest first, returned oldest first. The first poll looks back
+	// over the overlap window, before the stream started.
+	first := cursor.advance([]stripe.EventData{event("evt_b", 10), event("evt_a", 0), event("evt_before", -20)})
+	if got := eventIDs(first); len(got) != 2 || got[0] != "evt_a" || got[1] != "evt_b" {
+		t.Fatalf("expected evt_a, evt_b, got %v", got)
+	}
+
+	// The next poll lists the overlap window again, including an event
+	// Stripe made visible late
+	second := cursor.advance([]stripe.EventData{event("evt_d", 12), event("evt_b", 10), event("evt_late", 5), event("evt_a", 0), event("evt_before", -20)})
+	if got := eventIDs(second); len(got) != 2 || got[0] != "evt_late" || got[1] != "evt_d" {
+		t.Fatalf("expected evt_late, evt_d, got %v", got)
+	}
+	if want := start.Add(12*time.Second - streamOverlap); !cursor.from().Equal(want) {
+		t.Errorf("expected the next poll from %v, got %v", want, cursor.from())
+	}
+
+	if third := cursor.advance([]stripe.EventData{event("evt_d", 12)}); len(third) != 0 {
+		t.Errorf("expected no new events, got %v", eventIDs(third))
+	}
+
+	// Events older than the window are forgotten
+	cursor.advance([]stripe.EventData{event("evt_e", 130)})
+	if _, ok := cursor.seen["evt_a"]; ok || len(cursor.seen) != 1 {
+		t.Errorf("expected only evt_e remembered, got %v", cursor.seen)
+	}
+}
+
+func TestGrossVolumeTally(t *testing.T) {
+	day := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	charge := func(id string, at time.Time, amount int64, currency string) stripe.EventData {
+		return stripe.EventData{ID: "evt_" + id, ObjectID: id, Created: at, Amount: amount, HasAmount: true, Currency: currency}
+	}
+
+	var listed stripe.GrossVolume
+	listed.Add("ch_listed", 1000, "usd")
+	tally := &grossVolumeTally{day: day, volume: listed}
+
+	tally.apply(day.Add(12*time.Hour), []stripe.EventData{
+		charge("ch_listed", day.Add(time.Hour), 1000, "usd"), // Already in the charge list
+		charge("ch_new", day.Add(11*time.Hour), 500, "usd"),
+		charge("ch_eur", day.Add(11*time.Hour), 700, "eur"),
+		charge("ch_old", day.Add(-time.Minute), 300, "usd"), // Yesterday, within the overlap window
+	})
+	if tally.volume.Amount != 1500 || tally.volume.Payments != 2 {
+		t.Errorf("expected 1500 from 2 payments, got %d from %d", tally.volume.Amount, tally.volume.Payments)
+	}
+
+	// Midnight starts a new day; events from before it no longer count
+	next := day.AddDate(0, 0, 1)
+	tally.apply(next.Add(10*time.Second), []stripe.EventData{
+		charge("ch_late", next.Add(-5*time.Second), 200, "usd"),
+		charge("ch_today", next.Add(5*time.Second), 400, "usd"),
+	})
+	if !tally.day.Equal(next) || tally.volume.Amount != 400 || tally.volume.Payments != 1 {
+		t.Errorf("expected 400 from 1 payment on %v, got %d from %d on %v",
+			next, tally.volume.Amount, tally.volume.Payments, tally.day)
+	}
+}
+
+// eventIDs lists the IDs of events in order
+func eventIDs(events []stripe.EventData) []string {
+	var ids []string
+	for _, e := range events {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+func TestStreamEventTypes(t *testing.T) {
+	tests := []struct {
+		path  string
+		types []string
+		ok    bool
+	}{
+		{"events", nil, true},
+		{"events/charge.succeeded", []string{"charge.succeeded"}, true},
+		{"events/", nil, false},
+		{"events/a/b", nil, false},
+		{"gross_volume", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			types, ok := streamEventTypes(tt.path)
+			if ok != tt.ok || len(types) != len(tt.types) || (len(types) == 1 && types[0] != tt.types[0]) {
+				t.Errorf("expected %v %v, got %v %v", tt.types, tt.ok, types, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package plugin
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// applyTableOptions sorts a table frame and, when Stripe has more rows than
+// were returned, adds a notice with the cursor for the next page. Sorting
+// only reorders the rows of the current page.
+func applyTableOptions(frame *data.Frame, qm queryModel, hasMore bool) error {
+	if hasMore {
+		text := fmt.Sprintf("Showing the first %d rows; more are available.", frame.Rows())
+		if idField, idx := frame.FieldByName("id"); idx >= 0 && idField.Len() > 0 {
+			text += fmt.Sprintf(" Set Starting after to %v for the next page.", idField.At(idField.Len()-1))
+		}
+		addNotice(frame, text)
+	}
+	return sortFrame(frame, qm.SortBy, qm.SortDirection)
+}
+
+// addNotice adds an info notice to a frame
+func addNotice(frame *data.Frame, text string) {
+	if frame.Meta == nil {
+		frame.Meta = &data.FrameMeta{}
+	}
+	frame.Meta.Notices = append(frame.Meta.Notices, data.Notice{
+		Severity: data.NoticeSeverityInfo,
+		Text:     text,
+	})
+}
+
+// sortFrame reorders the rows of a table frame by the named field. An empty
+// field name leaves the frame in the order Stripe returned it.
+func sortFrame(frame *data.Frame, fieldName string, direction string) error {
+	if fieldName == "" {
+		return nil
+	}
+	field, idx := frame.FieldByName(fieldName)
+	if idx < 0 {
+		return fmt.Errorf("unknown sort field: %s", fieldName)
+	}
+	desc := strings.EqualFold(direction, "desc")
+
+	order := make([]int, field.Len())
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		c := compareValues(field.At(order[a]), field.At(order[b]))
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	for i, f := range frame.Fields {
+		sorted := data.NewFieldFromFieldType(f.Type(), f.Len())
+		sorted.Name = f.Name
+		sorted.Labels = f.Labels
+		sorted.Config = f.Config
+		for row, from := range order {
+			sorted.Set(row, f.At(from))
+		}
+		frame.Fields[i] = sorted
+	}
+	return nil
+}
+
+// compareValues orders two values of the same field type. Nulls sort first.
+func compareValues(a, b any) int {
+	switch av := a.(type) {
+	case string:
+		return strings.Compare(strings.ToLower(av), strings.ToLower(b.(string)))
+	case int64:
+		return cmp.Compare(av, b.(int64))
+	case float64:
+		return cmp.Compare(av, b.(float64))
+	case bool:
+		return cmp.Compare(boolInt(av), boolInt(b.(bool)))
+	case time.Time:
+		return av.Compare(b.(time.Time))
+	case *float64:
+		bv := b.(*float64)
+		if av == nil || bv == nil {
+			return cmp.Compare(boolInt(av != nil), boolInt(bv != nil))
+		}
+		return cmp.Compare(*av, *bv)
+	case *time.Time:
+		bv := b.(*time.Time)
+		if av == nil || bv == nil {
+			return cmp.Compare(boolInt(av != nil), boolInt(bv != nil))
+		}
+		return av.Compare(*bv)
+	}
+	return 0
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// optionalTime returns nil for the zero time so the cell renders empty
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
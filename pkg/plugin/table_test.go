@@ -0,0 +1,27 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+func TestSortFrame(t *testing.T) {
+	frame := data.NewFrame("customers",
+		data.NewField("id", nil, []string{"a", "b", "c"}),
+		data.NewField("mrr", nil, []float64{20, 30, 10}),
+	)
+
+	if err := sortFrame(frame, "mrr", "desc"); err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []string{"b", "a", "c"} {
+		if got := frame.Fields[0].At(i).(string); got != want {
+			t.Errorf("row %d: expected %s, got %s", i, want, got)
+		}
+	}
+
+	if err := sortFrame(frame, "missing", "asc"); err == nil {
+		t.Error("expected error for unknown sort field")
+	}
+}
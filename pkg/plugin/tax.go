@@ -0,0 +1,68 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// queryTax returns tax collected per bucket and tax rate over the panel range,
+// one row per combination
+func (d *Datasource) queryTax(ctx context.Context, q backend.DataQuery, qm queryModel) backend.DataResponse {
+	interval, err := bucketInterval(qm, q)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusBadRequest, err.Error())
+	}
+
+	taxes, err := d.client.GetTaxCollected(ctx, q.TimeRange.From, q.TimeRange.To, interval)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	frame := data.NewFrame("tax")
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualization: data.VisTypeTable,
+	}
+
+	n := len(taxes)
+	times := make([]time.Time, n)
+	rates := make([]string, n)
+	names := make([]string, n)
+	jurisdictions := make([]string, n)
+	countries := make([]string, n)
+	states := make([]string, n)
+	percentages := make([]float64, n)
+	inclusive := make([]bool, n)
+	taxable := make([]float64, n)
+	collected := make([]float64, n)
+
+	for i, t := range taxes {
+		times[i] = t.Time
+		rates[i] = t.TaxRate
+		names[i] = t.DisplayName
+		jurisdictions[i] = t.Jurisdiction
+		countries[i] = t.Country
+		states[i] = t.State
+		percentages[i] = t.Percentage
+		inclusive[i] = t.Inclusive
+		taxable[i] = float64(t.TaxableAmount) / 100
+		collected[i] = float64(t.Tax) / 100
+	}
+
+	frame.Fields = append(frame.Fields,
+		data.NewField("time", nil, times),
+		data.NewField("tax_rate", nil, rates),
+		data.NewField("name", nil, names),
+		data.NewField("jurisdiction", nil, jurisdictions),
+		data.NewField("country", nil, countries),
+		data.NewField("state", nil, states),
+		data.NewField("percentage", nil, percentages),
+		data.NewField("inclusive", nil, inclusive),
+		data.NewField("taxable_amount", nil, taxable),
+		data.NewField("tax", nil, collected),
+	)
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
@@ -0,0 +1,51 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+func (d *Datasource) queryTrialConversion(ctx context.Context, q backend.DataQuery) backend.DataResponse {
+	trials, err := d.client.GetTrialConversion(ctx, q.TimeRange.From, q.TimeRange.To)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	frame := data.NewFrame("trial_conversion")
+	frame.Meta = &data.FrameMeta{
+		PreferredVisualization: data.VisTypeTable,
+	}
+
+	prices := make([]string, len(trials))
+	totals := make([]int64, len(trials))
+	converted := make([]int64, len(trials))
+	canceled := make([]int64, len(trials))
+	pending := make([]int64, len(trials))
+	rates := make([]float64, len(trials))
+	avgDays := make([]float64, len(trials))
+
+	for i, t := range trials {
+		prices[i] = t.PriceName
+		totals[i] = t.Trials
+		converted[i] = t.Converted
+		canceled[i] = t.Canceled
+		pending[i] = t.Pending
+		rates[i] = t.ConversionRate
+		avgDays[i] = t.AvgTrialDays
+	}
+
+	frame.Fields = append(frame.Fields,
+		data.NewField("price", nil, prices),
+		data.NewField("trials", nil, totals),
+		data.NewField("converted", nil, converted),
+		data.NewField("canceled_during_trial", nil, canceled),
+		data.NewField("pending", nil, pending),
+		data.NewField("conversion_rate", nil, rates),
+		data.NewField("avg_trial_days", nil, avgDays),
+	)
+
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
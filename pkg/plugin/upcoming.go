@@ -0,0 +1,50 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/jfreels123/stripe-datasource/pkg/stripe"
+)
+
+// queryUpcomingRevenue returns the invoice amounts expected per bucket from
+// now to the end of the panel range
+func (d *Datasource) queryUpcomingRevenue(ctx context.Context, q backend.DataQuery, qm queryModel) backend.DataResponse {
+	if !q.TimeRange.To.After(time.Now()) {
+		return backend.ErrDataResponse(backend.StatusBadRequest, "upcoming revenue needs a time range ending in the future, e.g. now to now+30d")
+	}
+	interval, err := bucketInterval(qm, q)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusBadRequest, err.Error())
+	}
+
+	points, fallbacks, err := d.client.GetUpcomingRevenue(ctx, q.TimeRange.From, q.TimeRange.To, interval, qm.Preview)
+	if err != nil {
+		return backend.ErrDataResponse(backend.StatusInternal, fmt.Sprintf("stripe error: %v", err))
+	}
+
+	times := make([]time.Time, len(points))
+	expected := make([]float64, len(points))
+	invoices := make([]int64, len(points))
+	for i, p := range points {
+		times[i] = p.Time
+		expected[i] = float64(p.Expected) / 100
+		invoices[i] = p.Invoices
+	}
+
+	frame := data.NewFrame("upcoming_revenue",
+		data.NewField("time", nil, times),
+		data.NewField("Expected Revenue", nil, expected),
+		data.NewField("Invoices", nil, invoices),
+	)
+	if fallbacks.Failed > 0 {
+		addNotice(frame, fmt.Sprintf("%d invoice previews failed; those invoices are estimated at list price.", fallbacks.Failed))
+	}
+	if fallbacks.Skipped > 0 {
+		addNotice(frame, fmt.Sprintf("Previews are limited to %d subscriptions; %d more invoices are estimated at list price.", stripe.MaxInvoicePreviews, fallbacks.Skipped))
+	}
+	return backend.DataResponse{Frames: []*data.Frame{frame}}
+}
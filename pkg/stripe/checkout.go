@@ -0,0 +1,132 @@
+package stripe
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/checkout/session"
+)
+
+// Checkout session segments
+const (
+	CheckoutByPrice       = "price"
+	CheckoutByPaymentLink = "payment_link"
+)
+
+// CheckoutData represents checkout sessions created in one time bucket for one
+// segment. Segment is "All" when sessions are not segmented.
+type CheckoutData struct {
+	Time              time.Time
+	Segment           string
+	Sessions          int64
+	Open              int64
+	Complete          int64
+	Expired           int64
+	Paid              int64
+	Unpaid            int64
+	NoPaymentRequired int64
+	ConversionRate    float64 // Complete share of sessions that completed or expired
+	Revenue           int64   // Amount total of completed sessions
+}
+
+// GetCheckoutSessions counts checkout sessions created between from and to by
+// status and payment status, per bucket and optionally per price or payment link
+func (c *Client) GetCheckoutSessions(ctx context.Context, from, to time.Time, interval, segment string) ([]CheckoutData, error) {
+	stripe.Key = c.key
+
+	params := &stripe.CheckoutSessionListParams{
+		CreatedRange: &stripe.RangeQueryParams{
+			GreaterThanOrEqual: from.Unix(),
+			LesserThanOrEqual:  to.Unix(),
+		},
+	}
+	if segment == CheckoutByPrice {
+		params.Expand = []*string{stripe.String("data.line_items")}
+	}
+	params.Context = ctx
+
+	var sessions []*stripe.CheckoutSession
+	iter := session.List(params)
+	for iter.Next() {
+		sessions = append(sessions, iter.CheckoutSession())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	return summarizeCheckout(sessions, interval, segment), nil
+}
+
+// summarizeCheckout tallies sessions per bucket and segment. A session with
+// several prices counts once for each, with the revenue of that line.
+func summarizeCheckout(sessions []*stripe.CheckoutSession, interval, segment string) []CheckoutData {
+	type key struct {
+		bucket  time.Time
+		segment string
+	}
+	groups := make(map[key]*CheckoutData)
+	add := func(cs *stripe.CheckoutSession, bucket time.Time, seg string, revenue int64) {
+		k := key{bucket: bucket, segment: seg}
+		cd, ok := groups[k]
+		if !ok {
+			cd = &CheckoutData{Time: bucket, Segment: seg}
+			groups[k] = cd
+		}
+		cd.Sessions++
+		switch cs.Status {
+		case stripe.CheckoutSessionStatusOpen:
+			cd.Open++
+		case stripe.CheckoutSessionStatusComplete:
+			cd.Complete++
+			cd.Revenue += revenue
+		case stripe.CheckoutSessionStatusExpired:
+			cd.Expired++
+		}
+		switch cs.PaymentStatus {
+		case stripe.CheckoutSessionPaymentStatusPaid:
+			cd.Paid++
+		case stripe.CheckoutSessionPaymentStatusUnpaid:
+			cd.Unpaid++
+		case stripe.CheckoutSessionPaymentStatusNoPaymentRequired:
+			cd.NoPaymentRequired++
+		}
+	}
+
+	for _, cs := range sessions {
+		bucket := bucketStart(time.Unix(cs.Created, 0), interval)
+		switch segment {
+		case CheckoutByPrice:
+			if cs.LineItems == nil || len(cs.LineItems.Data) == 0 {
+				add(cs, bucket, "none", cs.AmountTotal)
+				continue
+			}
+			for _, li := range cs.LineItems.Data {
+				add(cs, bucket, priceLabel(li.Price), li.AmountTotal)
+			}
+		case CheckoutByPaymentLink:
+			link := "none"
+			if cs.PaymentLink != nil && cs.PaymentLink.ID != "" {
+				link = cs.PaymentLink.ID
+			}
+			add(cs, bucket, link, cs.AmountTotal)
+		default:
+			add(cs, bucket, "All", cs.AmountTotal)
+		}
+	}
+
+	result := make([]CheckoutData, 0, len(groups))
+	for _, cd := range groups {
+		if decided := cd.Complete + cd.Expired; decided > 0 {
+			cd.ConversionRate = float64(cd.Complete) / float64(decided) * 100
+		}
+		result = append(result, *cd)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].Time.Equal(result[j].Time) {
+			return result[i].Time.Before(result[j].Time)
+		}
+		return result[i].Segment < result[j].Segment
+	})
+	return result
+}
@@ -0,0 +1,43 @@
+package stripe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+func TestSummarizeCheckout(t *testing.T) {
+	day := time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC).Unix()
+	session := func(status stripe.CheckoutSessionStatus, payment stripe.CheckoutSessionPaymentStatus, link string, amount int64) *stripe.CheckoutSession {
+		cs := &stripe.CheckoutSession{Created: day, Status: status, PaymentStatus: payment, AmountTotal: amount}
+		if link != "" {
+			cs.PaymentLink = &stripe.PaymentLink{ID: link}
+		}
+		return cs
+	}
+	sessions := []*stripe.CheckoutSession{
+		session(stripe.CheckoutSessionStatusComplete, stripe.CheckoutSessionPaymentStatusPaid, "plink_a", 1000),
+		session(stripe.CheckoutSessionStatusComplete, stripe.CheckoutSessionPaymentStatusPaid, "plink_a", 1000),
+		session(stripe.CheckoutSessionStatusExpired, stripe.CheckoutSessionPaymentStatusUnpaid, "plink_a", 1000),
+		session(stripe.CheckoutSessionStatusOpen, stripe.CheckoutSessionPaymentStatusUnpaid, "", 500),
+	}
+
+	all := summarizeCheckout(sessions, IntervalDay, "")
+	if len(all) != 1 {
+		t.Fatalf("got %d rows, want 1", len(all))
+	}
+	got := all[0]
+	if got.Sessions != 4 || got.Open != 1 || got.Complete != 2 || got.Expired != 1 || got.Paid != 2 || got.Unpaid != 2 {
+		t.Errorf("unexpected counts: %+v", got)
+	}
+	// Open sessions have not converted or expired yet
+	if got.Revenue != 2000 || int(got.ConversionRate) != 66 {
+		t.Errorf("got revenue %d conversion %.1f, want 2000 and 66.7", got.Revenue, got.ConversionRate)
+	}
+
+	byLink := summarizeCheckout(sessions, IntervalDay, CheckoutByPaymentLink)
+	if len(byLink) != 2 || byLink[0].Segment != "none" || byLink[1].Segment != "plink_a" || byLink[1].Sessions != 3 {
+		t.Errorf("unexpected payment link rows: %+v", byLink)
+	}
+}
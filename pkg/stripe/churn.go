@@ -0,0 +1,186 @@
+package stripe
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+// ChurnStats represents subscriptions paying at the start of a period and how
+// many of them ended before its end
+type ChurnStats struct {
+	StartingCount int64
+	StartingMRR   int64
+	ChurnedCount  int64
+	ChurnedMRR    int64
+	// Churn split by cancellation reason, see churnType
+	VoluntaryCount   int64
+	VoluntaryMRR     int64
+	InvoluntaryCount int64
+	InvoluntaryMRR   int64
+}
+
+// LogoChurnRate returns the percentage of starting subscriptions that ended
+func (cs ChurnStats) LogoChurnRate() float64 {
+	if cs.StartingCount == 0 {
+		return 0
+	}
+	return float64(cs.ChurnedCount) / float64(cs.StartingCount) * 100
+}
+
+// RevenueChurnRate returns the percentage of starting MRR that ended
+func (cs ChurnStats) RevenueChurnRate() float64 {
+	if cs.StartingMRR == 0 {
+		return 0
+	}
+	return float64(cs.ChurnedMRR) / float64(cs.StartingMRR) * 100
+}
+
+// VoluntaryChurnRate returns the percentage of starting subscriptions that
+// ended because the customer or merchant canceled
+func (cs ChurnStats) VoluntaryChurnRate() float64 {
+	if cs.StartingCount == 0 {
+		return 0
+	}
+	return float64(cs.VoluntaryCount) / float64(cs.StartingCount) * 100
+}
+
+// InvoluntaryChurnRate returns the percentage of starting subscriptions that
+// ended because of failed or disputed payments
+func (cs ChurnStats) InvoluntaryChurnRate() float64 {
+	if cs.StartingCount == 0 {
+		return 0
+	}
+	return float64(cs.InvoluntaryCount) / float64(cs.StartingCount) * 100
+}
+
+// GetChurn returns churn between from and to, reconstructing the set of
+// subscriptions that were paying at from
+func (c *Client) GetChurn(ctx context.Context, from, to time.Time) (*ChurnStats, error) {
+	stripe.Key = c.key
+
+	subs, err := c.listAllSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	churn := calculateChurn(subs, from.Unix(), to.Unix())
+	return &churn, nil
+}
+
+// calculateChurn finds subscriptions paying at start from their start and end
+// dates, then counts those that ended in (start, end]
+func calculateChurn(subs []*stripe.Subscription, start, end int64) ChurnStats {
+	var cs ChurnStats
+	for _, s := range subs {
+		if !payingAt(s, start) {
+			continue
+		}
+		mrr := calculateMRR(s)
+		cs.StartingCount++
+		cs.StartingMRR += mrr
+		if s.EndedAt > start && s.EndedAt <= end {
+			cs.ChurnedCount++
+			cs.ChurnedMRR += mrr
+			if churnType(s) == ChurnInvoluntary {
+				cs.InvoluntaryCount++
+				cs.InvoluntaryMRR += mrr
+			} else {
+				cs.VoluntaryCount++
+				cs.VoluntaryMRR += mrr
+			}
+		}
+	}
+	return cs
+}
+
+// payingAt reports whether the subscription was active and past any trial at t
+func payingAt(s *stripe.Subscription, t int64) bool {
+	switch s.Status {
+	case stripe.SubscriptionStatusIncomplete, stripe.SubscriptionStatusIncompleteExpired:
+		return false
+	}
+	if s.TrialEnd > t {
+		return false
+	}
+	return activeAt(s, t)
+}
+
+const (
+	ChurnVoluntary   = "voluntary"
+	ChurnInvoluntary = "involuntary"
+)
+
+// churnType classifies a cancellation by its reason. Payment failures and
+// disputes are involuntary; everything else, including subscriptions canceled
+// before Stripe recorded a reason, counts as voluntary.
+func churnType(s *stripe.Subscription) string {
+	if s.CancellationDetails != nil {
+		switch s.CancellationDetails.Reason {
+		case stripe.SubscriptionCancellationDetailsReasonPaymentFailed,
+			stripe.SubscriptionCancellationDetailsReasonPaymentDisputed:
+			return ChurnInvoluntary
+		}
+	}
+	return ChurnVoluntary
+}
+
+// CancellationData represents subscriptions that ended for one reason and
+// customer feedback code
+type CancellationData struct {
+	Type     string // ChurnVoluntary or ChurnInvoluntary
+	Reason   string
+	Feedback string
+	Count    int64
+	MRR      int64
+}
+
+// GetCancellationBreakdown returns subscriptions that ended between from and
+// to grouped by cancellation reason and feedback, largest MRR first
+func (c *Client) GetCancellationBreakdown(ctx context.Context, from, to time.Time) ([]CancellationData, error) {
+	stripe.Key = c.key
+
+	subs, err := c.listAllSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeCancellations(subs, from.Unix(), to.Unix()), nil
+}
+
+// summarizeCancellations groups subscriptions that ended between from and to
+// by cancellation reason and feedback, largest MRR first
+func summarizeCancellations(subs []*stripe.Subscription, from, to int64) []CancellationData {
+	type key struct{ reason, feedback string }
+	groups := make(map[key]*CancellationData)
+	for _, s := range subs {
+		if s.EndedAt < from || s.EndedAt > to {
+			continue
+		}
+		// Only paying subscriptions churn, as in calculateChurn
+		if !payingAt(s, s.EndedAt-1) {
+			continue
+		}
+		k := key{}
+		if s.CancellationDetails != nil {
+			k.reason = string(s.CancellationDetails.Reason)
+			k.feedback = string(s.CancellationDetails.Feedback)
+		}
+		cd, ok := groups[k]
+		if !ok {
+			cd = &CancellationData{Type: churnType(s), Reason: k.reason, Feedback: k.feedback}
+			groups[k] = cd
+		}
+		cd.Count++
+		cd.MRR += calculateMRR(s)
+	}
+
+	result := make([]CancellationData, 0, len(groups))
+	for _, cd := range groups {
+		result = append(result, *cd)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].MRR > result[j].MRR
+	})
+	return result
+}
@@ -0,0 +1,112 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+func TestCalculateChurn(t *testing.T) {
+	const start, end = int64(1000), int64(2000)
+
+	sub := func(created, trialEnd, ended, amount int64) *stripe.Subscription {
+		return &stripe.Subscription{
+			Created:  created,
+			TrialEnd: trialEnd,
+			EndedAt:  ended,
+			Items: &stripe.SubscriptionItemList{Data: []*stripe.SubscriptionItem{{
+				Quantity: 1,
+				Price: &stripe.Price{
+					UnitAmount: amount,
+					Recurring:  &stripe.PriceRecurring{Interval: stripe.PriceRecurringIntervalMonth},
+				},
+			}}},
+		}
+	}
+
+	subs := []*stripe.Subscription{
+		sub(500, 0, 0, 100),     // retained
+		sub(500, 0, 1500, 300),  // churned in period
+		sub(500, 0, 900, 300),   // ended before period
+		sub(1500, 0, 1800, 300), // new in period
+		sub(500, 1200, 0, 300),  // in trial at start
+	}
+
+	cs := calculateChurn(subs, start, end)
+	if cs.StartingCount != 2 || cs.ChurnedCount != 1 {
+		t.Errorf("expected 1 of 2 churned, got %+v", cs)
+	}
+	if cs.LogoChurnRate() != 50 {
+		t.Errorf("expected 50%% logo churn, got %v", cs.LogoChurnRate())
+	}
+	if cs.RevenueChurnRate() != 75 {
+		t.Errorf("expected 75%% revenue churn, got %v", cs.RevenueChurnRate())
+	}
+}
+
+func TestChurnType(t *testing.T) {
+	tests := []struct {
+		name    string
+		details *stripe.SubscriptionCancellationDetails
+		want    string
+	}{
+		{"payment failed", &stripe.SubscriptionCancellationDetails{Reason: stripe.SubscriptionCancellationDetailsReasonPaymentFailed}, ChurnInvoluntary},
+		{"payment disputed", &stripe.SubscriptionCancellationDetails{Reason: stripe.SubscriptionCancellationDetailsReasonPaymentDisputed}, ChurnInvoluntary},
+		{"cancellation requested", &stripe.SubscriptionCancellationDetails{Reason: stripe.SubscriptionCancellationDetailsReasonCancellationRequested}, ChurnVoluntary},
+		{"empty reason", &stripe.SubscriptionCancellationDetails{}, ChurnVoluntary},
+		{"no details", nil, ChurnVoluntary},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := churnType(&stripe.Subscription{CancellationDetails: tt.details}); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeCancellations(t *testing.T) {
+	sub := func(ended int64, reason stripe.SubscriptionCancellationDetailsReason, amount int64) *stripe.Subscription {
+		return &stripe.Subscription{
+			EndedAt:             ended,
+			CancellationDetails: &stripe.SubscriptionCancellationDetails{Reason: reason},
+			Items: &stripe.SubscriptionItemList{Data: []*stripe.SubscriptionItem{{
+				Quantity: 1,
+				Price: &stripe.Price{
+					UnitAmount: amount,
+					Recurring:  &stripe.PriceRecurring{Interval: stripe.PriceRecurringIntervalMonth},
+				},
+			}}},
+		}
+	}
+	// Never paid, so not churn
+	expired := sub(1100, "", 400)
+	expired.Status = stripe.SubscriptionStatusIncompleteExpired
+	unpaidTrial := sub(1200, stripe.SubscriptionCancellationDetailsReasonCancellationRequested, 800)
+	unpaidTrial.TrialEnd = 1300
+
+	got := summarizeCancellations([]*stripe.Subscription{
+		sub(1500, stripe.SubscriptionCancellationDetailsReasonPaymentFailed, 300),
+		sub(1600, stripe.SubscriptionCancellationDetailsReasonPaymentFailed, 200),
+		sub(1700, stripe.SubscriptionCancellationDetailsReasonCancellationRequested, 1000),
+		sub(1800, "", 100),
+		sub(900, stripe.SubscriptionCancellationDetailsReasonCancellationRequested, 5000), // ended before the range
+		sub(0, "", 5000), // still running
+		expired,
+		unpaidTrial,
+	}, 1000, 2000)
+
+	want := []CancellationData{
+		{Type: ChurnVoluntary, Reason: "cancellation_requested", Count: 1, MRR: 1000},
+		{Type: ChurnInvoluntary, Reason: "payment_failed", Count: 2, MRR: 500},
+		{Type: ChurnVoluntary, Reason: "", Count: 1, MRR: 100},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d groups, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("group %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -31,11 +31,35 @@ type Metrics struct {
 	NewMRR           int64   // MRR from subs created in last 30 days
 	ChurnedMRR       int64   // MRR lost from canceled subs in last 30 days
 	NetNewMRR        int64   // New MRR - Churned MRR
-	ChurnRate        float64 // Churned / (Active 30 days ago + New)
+	ChurnRate        float64 // Subs ended in last 30 days / paying subs 30 days ago
 	ARPU             int64   // MRR / ActiveSubscribers
 	TrialingCount    int64   // Subscriptions currently in trial
 	PastDueCount     int64   // Subscriptions past due
 	CanceledCount30d int64   // Canceled in last 30 days
+	PendingChurnMRR  int64   // MRR of active subs scheduled to cancel
+}
+
+// LTV returns customer lifetime value: ARPU times gross margin divided by the
+// monthly churn rate. Zero when there has been no churn to measure.
+func (m *Metrics) LTV(grossMarginPercent float64) int64 {
+	if m.ChurnRate <= 0 {
+		return 0
+	}
+	return int64(float64(m.ARPU) * grossMarginPercent / m.ChurnRate)
+}
+
+// CommittedMRR returns MRR excluding subscriptions scheduled to cancel
+func (m *Metrics) CommittedMRR() int64 {
+	return m.MRR - m.PendingChurnMRR
+}
+
+// AvgCustomerLifetime returns the expected customer lifetime in months,
+// the inverse of the monthly churn rate
+func (m *Metrics) AvgCustomerLifetime() float64 {
+	if m.ChurnRate <= 0 {
+		return 0
+	}
+	return 100 / m.ChurnRate
 }
 
 type SubscriptionData struct {
@@ -54,20 +78,31 @@ func (c *Client) GetMetrics(ctx context.Context) (*Metrics, error) {
 	m := &Metrics{}
 	thirtyDaysAgo := time.Now().AddDate(0, 0, -30).Unix()
 
-	// Get active subscriptions for MRR calculation
-	subs, err := c.listActiveSubscriptions(ctx)
+	// Walk every subscription once; MRR, status counts and churn all come
+	// from the same list
+	subs, err := c.listAllSubscriptions(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, s := range subs {
-		mrr := calculateMRR(s)
-		m.MRR += mrr
-		m.ActiveSubscribers++
+		switch s.Status {
+		case stripe.SubscriptionStatusActive:
+			mrr := calculateMRR(s)
+			m.MRR += mrr
+			m.ActiveSubscribers++
 
-		// New MRR = subscriptions created in last 30 days
-		if s.Created >= thirtyDaysAgo {
-			m.NewMRR += mrr
+			// New MRR = subscriptions created in last 30 days
+			if s.Created >= thirtyDaysAgo {
+				m.NewMRR += mrr
+			}
+			if pendingCancelAt(s) > 0 {
+				m.PendingChurnMRR += mrr
+			}
+		case stripe.SubscriptionStatusTrialing:
+			m.TrialingCount++
+		case stripe.SubscriptionStatusPastDue:
+			m.PastDueCount++
 		}
 	}
 	m.ARR = m.MRR * 12
@@ -77,35 +112,15 @@ func (c *Client) GetMetrics(ctx context.Context) (*Metrics, error) {
 		m.ARPU = m.MRR / m.ActiveSubscribers
 	}
 
-	// Get trialing subscriptions
-	trialing, err := c.countSubscriptionsByStatus(ctx, "trialing")
-	if err != nil {
-		return nil, err
-	}
-	m.TrialingCount = trialing
-
-	// Get past due subscriptions
-	pastDue, err := c.countSubscriptionsByStatus(ctx, "past_due")
-	if err != nil {
-		return nil, err
-	}
-	m.PastDueCount = pastDue
-
-	// Get canceled subscriptions in last 30 days for churn
-	canceled, churnedMRR, err := c.getCanceledSubscriptions(ctx, thirtyDaysAgo)
-	if err != nil {
-		return nil, err
-	}
+	// Get canceled subscriptions in last 30 days
+	canceled, churnedMRR := getCanceledSubscriptions(subs, thirtyDaysAgo)
 	m.CanceledCount30d = canceled
 	m.ChurnedMRR = churnedMRR
 	m.NetNewMRR = m.NewMRR - m.ChurnedMRR
 
-	// Calculate churn rate: churned / (active 30 days ago + new)
-	// Approximate: active 30 days ago ≈ current active - new + churned
-	active30DaysAgo := m.ActiveSubscribers - (m.NewMRR / max(m.ARPU, 1)) + m.CanceledCount30d
-	if active30DaysAgo > 0 {
-		m.ChurnRate = float64(m.CanceledCount30d) / float64(active30DaysAgo) * 100
-	}
+	// Churn rate against the subscriptions that were actually paying 30 days ago
+	churn := calculateChurn(subs, thirtyDaysAgo, time.Now().Unix())
+	m.ChurnRate = churn.LogoChurnRate()
 
 	// Get customer count
 	customers, err := c.countCustomers(ctx)
@@ -125,89 +140,95 @@ func (c *Client) GetMetrics(ctx context.Context) (*Metrics, error) {
 	return m, nil
 }
 
-func (c *Client) countSubscriptionsByStatus(ctx context.Context, status string) (int64, error) {
-	params := &stripe.SubscriptionListParams{
-		Status: stripe.String(status),
-	}
-	params.Context = ctx
-
+// getCanceledSubscriptions counts subscriptions canceled since the given time
+// and the MRR they carried
+func getCanceledSubscriptions(subs []*stripe.Subscription, since int64) (int64, int64) {
 	var count int64
-	iter := subscription.List(params)
-	for iter.Next() {
-		count++
+	var churnedMRR int64
+	for _, s := range subs {
+		// Only count if canceled in the time window
+		if s.Status == stripe.SubscriptionStatusCanceled && s.CanceledAt >= since {
+			count++
+			churnedMRR += calculateMRR(s)
+		}
 	}
-	return count, iter.Err()
+	return count, churnedMRR
 }
 
-func (c *Client) getCanceledSubscriptions(ctx context.Context, since int64) (int64, int64, error) {
+// GetSubscriptions returns one page of active subscriptions and whether more remain
+func (c *Client) GetSubscriptions(ctx context.Context, opts ListOptions) ([]SubscriptionData, bool, error) {
+	stripe.Key = c.key
+
 	params := &stripe.SubscriptionListParams{
-		Status: stripe.String("canceled"),
+		ListParams: opts.listParams(ctx),
+		Status:     stripe.String("active"),
 	}
 	params.Expand = []*string{
 		stripe.String("data.items.data.price"),
 	}
-	params.Context = ctx
 
-	var count int64
-	var churnedMRR int64
+	var result []SubscriptionData
 	iter := subscription.List(params)
-	for iter.Next() {
-		s := iter.Subscription()
-		// Only count if canceled in the time window
-		if s.CanceledAt >= since {
-			count++
-			churnedMRR += calculateMRR(s)
-		}
-	}
-	return count, churnedMRR, iter.Err()
+	hasMore, err := paginate(iter, opts.limit(), func() {
+		result = append(result, toSubscriptionData(iter.Subscription()))
+	})
+	return result, hasMore, err
 }
 
-func max(a, b int64) int64 {
-	if a > b {
-		return a
+// toSubscriptionData flattens a subscription for table output
+func toSubscriptionData(s *stripe.Subscription) SubscriptionData {
+	sd := SubscriptionData{
+		ID:      s.ID,
+		Status:  string(s.Status),
+		MRR:     calculateMRR(s),
+		Created: time.Unix(s.Created, 0),
+	}
+	if s.Customer != nil {
+		sd.Customer = s.Customer.ID
+	}
+	if len(s.Items.Data) > 0 {
+		item := s.Items.Data[0]
+		if item.Price != nil {
+			if item.Price.Recurring != nil {
+				sd.Interval = string(item.Price.Recurring.Interval)
+			}
+			sd.PlanName = item.Price.Nickname
+			if sd.PlanName == "" && item.Price.Product != nil {
+				sd.PlanName = item.Price.Product.ID
+			}
+		}
 	}
-	return b
+	return sd
 }
 
-func (c *Client) GetSubscriptions(ctx context.Context) ([]SubscriptionData, error) {
-	stripe.Key = c.key
-
-	subs, err := c.listActiveSubscriptions(ctx)
-	if err != nil {
-		return nil, err
+// listActiveSubscriptions returns active subscriptions with their prices and
+// any other given fields expanded
+func (c *Client) listActiveSubscriptions(ctx context.Context, expand ...string) ([]*stripe.Subscription, error) {
+	params := &stripe.SubscriptionListParams{
+		Status: stripe.String("active"),
+	}
+	// Only expand to 4 levels (Stripe's limit)
+	params.Expand = []*string{
+		stripe.String("data.items.data.price"),
 	}
+	for _, f := range expand {
+		params.AddExpand(f)
+	}
+	params.Context = ctx
 
-	result := make([]SubscriptionData, 0, len(subs))
-	for _, s := range subs {
-		sd := SubscriptionData{
-			ID:       s.ID,
-			Status:   string(s.Status),
-			Customer: s.Customer.ID,
-			MRR:      calculateMRR(s),
-			Created:  time.Unix(s.Created, 0),
-		}
-		if len(s.Items.Data) > 0 {
-			item := s.Items.Data[0]
-			if item.Price != nil {
-				if item.Price.Recurring != nil {
-					sd.Interval = string(item.Price.Recurring.Interval)
-				}
-				sd.PlanName = item.Price.Nickname
-				if sd.PlanName == "" && item.Price.Product != nil {
-					sd.PlanName = item.Price.Product.ID
-				}
-			}
-		}
-		result = append(result, sd)
+	var subs []*stripe.Subscription
+	iter := subscription.List(params)
+	for iter.Next() {
+		subs = append(subs, iter.Subscription())
 	}
-	return result, nil
+	return subs, iter.Err()
 }
 
-func (c *Client) listActiveSubscriptions(ctx context.Context) ([]*stripe.Subscription, error) {
+// listAllSubscriptions returns subscriptions in every status, including canceled
+func (c *Client) listAllSubscriptions(ctx context.Context) ([]*stripe.Subscription, error) {
 	params := &stripe.SubscriptionListParams{
-		Status: stripe.String("active"),
+		Status: stripe.String("all"),
 	}
-	// Only expand to 4 levels (Stripe's limit)
 	params.Expand = []*string{
 		stripe.String("data.items.data.price"),
 	}
@@ -269,25 +290,48 @@ func calculateMRR(s *stripe.Subscription) int64 {
 
 	var total int64
 	for _, item := range s.Items.Data {
-		if item.Price == nil || item.Price.Recurring == nil {
-			continue
-		}
-		amount := item.Price.UnitAmount * item.Quantity
-
-		switch item.Price.Recurring.Interval {
-		case stripe.PriceRecurringIntervalYear:
-			total += amount / 12
-		case stripe.PriceRecurringIntervalMonth:
-			total += amount
-		case stripe.PriceRecurringIntervalWeek:
-			total += amount * 4
-		case stripe.PriceRecurringIntervalDay:
-			total += amount * 30
-		}
+		total += itemMRR(item)
 	}
 	return total
 }
 
+// itemMRR normalizes a single subscription item amount to monthly
+func itemMRR(item *stripe.SubscriptionItem) int64 {
+	if item.Price == nil || item.Price.Recurring == nil {
+		return 0
+	}
+	return monthlyAmount(item.Price.UnitAmount*item.Quantity, item.Price.Recurring.Interval)
+}
+
+// monthlyAmount normalizes an amount billed every interval to a month
+func monthlyAmount(amount int64, interval stripe.PriceRecurringInterval) int64 {
+	switch interval {
+	case stripe.PriceRecurringIntervalYear:
+		return amount / 12
+	case stripe.PriceRecurringIntervalMonth:
+		return amount
+	case stripe.PriceRecurringIntervalWeek:
+		return amount * 4
+	case stripe.PriceRecurringIntervalDay:
+		return amount * 30
+	}
+	return 0
+}
+
+// priceLabel returns the price nickname, falling back to product and price IDs
+func priceLabel(p *stripe.Price) string {
+	if p == nil {
+		return ""
+	}
+	if p.Nickname != "" {
+		return p.Nickname
+	}
+	if p.Product != nil && p.Product.ID != "" {
+		return p.Product.ID
+	}
+	return p.ID
+}
+
 // Ping tests the API connection
 func (c *Client) Ping(ctx context.Context) error {
 	stripe.Key = c.key
@@ -304,6 +348,7 @@ type InvoiceData struct {
 	Status       string
 	Amount       int64
 	AmountPaid   int64
+	Tax          int64
 	Currency     string
 	Created      time.Time
 	DueDate      time.Time
@@ -311,69 +356,57 @@ type InvoiceData struct {
 	ProductName  string
 }
 
-// GetInvoices returns recent invoices
-func (c *Client) GetInvoices(ctx context.Context) ([]InvoiceData, error) {
+// GetInvoices returns one page of recent invoices and whether more remain
+func (c *Client) GetInvoices(ctx context.Context, opts ListOptions) ([]InvoiceData, bool, error) {
 	stripe.Key = c.key
 
-	params := &stripe.InvoiceListParams{}
-	params.Limit = stripe.Int64(100)
-	params.Context = ctx
+	params := &stripe.InvoiceListParams{
+		ListParams: opts.listParams(ctx),
+	}
 
-	var invoices []InvoiceData
+	var page []*stripe.Invoice
 	iter := invoice.List(params)
-	for iter.Next() {
-		inv := iter.Invoice()
-		isPaid := inv.Status == stripe.InvoiceStatusPaid
-		data := InvoiceData{
-			ID:         inv.ID,
-			Status:     string(inv.Status),
-			Amount:     inv.Total,
-			AmountPaid: inv.AmountPaid,
-			Currency:   string(inv.Currency),
-			Created:    time.Unix(inv.Created, 0),
-			Paid:       isPaid,
-		}
-		if inv.Customer != nil {
-			data.Customer = inv.Customer.ID
-		}
-		if inv.DueDate > 0 {
-			data.DueDate = time.Unix(inv.DueDate, 0)
-		}
-		invoices = append(invoices, data)
+	hasMore, err := paginate(iter, opts.limit(), func() {
+		page = append(page, iter.Invoice())
+	})
+	if err != nil {
+		return nil, false, err
 	}
-	return invoices, iter.Err()
-}
-
-// InvoiceMetrics represents aggregated invoice metrics
-type InvoiceMetrics struct {
-	TotalRevenue    int64
-	PaidInvoices    int64
-	UnpaidInvoices  int64
-	OverdueInvoices int64
-}
-
-// GetInvoiceMetrics returns aggregated invoice metrics
-func (c *Client) GetInvoiceMetrics(ctx context.Context) (*InvoiceMetrics, error) {
-	stripe.Key = c.key
 
-	params := &stripe.InvoiceListParams{}
-	params.Context = ctx
+	names, err := c.getProductNames(ctx, invoiceProductIDs(page))
+	if err != nil {
+		return nil, false, err
+	}
 
-	m := &InvoiceMetrics{}
-	iter := invoice.List(params)
-	for iter.Next() {
-		inv := iter.Invoice()
-		if inv.Status == stripe.InvoiceStatusPaid {
-			m.TotalRevenue += inv.AmountPaid
-			m.PaidInvoices++
-		} else {
-			m.UnpaidInvoices++
-			if inv.DueDate > 0 && time.Unix(inv.DueDate, 0).Before(time.Now()) {
-				m.OverdueInvoices++
-			}
-		}
+	invoices := make([]InvoiceData, len(page))
+	for i, inv := range page {
+		invoices[i] = toInvoiceData(inv, names)
 	}
-	return m, iter.Err()
+	return invoices, hasMore, nil
+}
+
+// toInvoiceData flattens an invoice for table output. productNames maps
+// product IDs to names for the ProductName column.
+func toInvoiceData(inv *stripe.Invoice, productNames map[string]string) InvoiceData {
+	isPaid := inv.Status == stripe.InvoiceStatusPaid
+	data := InvoiceData{
+		ID:          inv.ID,
+		Status:      string(inv.Status),
+		Amount:      inv.Total,
+		AmountPaid:  inv.AmountPaid,
+		Tax:         invoiceTax(inv),
+		Currency:    string(inv.Currency),
+		Created:     time.Unix(inv.Created, 0),
+		Paid:        isPaid,
+		ProductName: invoiceProductNames(inv, productNames),
+	}
+	if inv.Customer != nil {
+		data.Customer = inv.Customer.ID
+	}
+	if inv.DueDate > 0 {
+		data.DueDate = time.Unix(inv.DueDate, 0)
+	}
+	return data
 }
 
 // ChargeData represents charge information
@@ -388,33 +421,37 @@ type ChargeData struct {
 	Refunded bool
 }
 
-// GetCharges returns recent charges
-func (c *Client) GetCharges(ctx context.Context) ([]ChargeData, error) {
+// GetCharges returns one page of recent charges and whether more remain
+func (c *Client) GetCharges(ctx context.Context, opts ListOptions) ([]ChargeData, bool, error) {
 	stripe.Key = c.key
 
-	params := &stripe.ChargeListParams{}
-	params.Limit = stripe.Int64(100)
-	params.Context = ctx
+	params := &stripe.ChargeListParams{
+		ListParams: opts.listParams(ctx),
+	}
 
 	var charges []ChargeData
 	iter := charge.List(params)
-	for iter.Next() {
-		ch := iter.Charge()
-		data := ChargeData{
-			ID:       ch.ID,
-			Amount:   ch.Amount,
-			Currency: string(ch.Currency),
-			Status:   string(ch.Status),
-			Created:  time.Unix(ch.Created, 0),
-			Paid:     ch.Paid,
-			Refunded: ch.Refunded,
-		}
-		if ch.Customer != nil {
-			data.Customer = ch.Customer.ID
-		}
-		charges = append(charges, data)
-	}
-	return charges, iter.Err()
+	hasMore, err := paginate(iter, opts.limit(), func() {
+		charges = append(charges, toChargeData(iter.Charge()))
+	})
+	return charges, hasMore, err
+}
+
+// toChargeData flattens a charge for table output
+func toChargeData(ch *stripe.Charge) ChargeData {
+	data := ChargeData{
+		ID:       ch.ID,
+		Amount:   ch.Amount,
+		Currency: string(ch.Currency),
+		Status:   string(ch.Status),
+		Created:  time.Unix(ch.Created, 0),
+		Paid:     ch.Paid,
+		Refunded: ch.Refunded,
+	}
+	if ch.Customer != nil {
+		data.Customer = ch.Customer.ID
+	}
+	return data
 }
 
 // ChargeMetrics represents aggregated charge metrics
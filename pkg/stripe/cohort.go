@@ -0,0 +1,163 @@
+package stripe
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+// CohortData represents retention for customers whose first subscription
+// started in the same month. Index 0 of each retention slice is the signup
+// month itself; later indexes are months since signup, up to the current month.
+type CohortData struct {
+	Cohort           time.Time
+	Customers        int64
+	StartingMRR      int64
+	LogoRetention    []float64 // % of cohort customers still subscribed
+	RevenueRetention []float64 // % of starting MRR still billed
+}
+
+// GetCohortRetention returns monthly cohorts with a signup month between from and to
+func (c *Client) GetCohortRetention(ctx context.Context, from, to time.Time) ([]CohortData, error) {
+	stripe.Key = c.key
+
+	subs, err := c.listAllSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return buildCohorts(subs, from, to, time.Now()), nil
+}
+
+// buildCohorts groups customers by the month of their first subscription and
+// measures, at the start of every following month, how many still have a
+// subscription and how much MRR it carries
+func buildCohorts(subs []*stripe.Subscription, from, to, now time.Time) []CohortData {
+	byCustomer := make(map[string][]*stripe.Subscription)
+	for _, s := range subs {
+		if s.Customer == nil || !paying(s) {
+			continue
+		}
+		byCustomer[s.Customer.ID] = append(byCustomer[s.Customer.ID], s)
+	}
+
+	type cohortTally struct {
+		customers   int64
+		startingMRR int64
+		retained    []int64
+		retainedMRR []int64
+	}
+
+	cohorts := make(map[time.Time]*cohortTally)
+	for _, customerSubs := range byCustomer {
+		first := subscriptionStart(customerSubs[0])
+		for _, s := range customerSubs[1:] {
+			if start := subscriptionStart(s); start < first {
+				first = start
+			}
+		}
+		cohort := monthStart(time.Unix(first, 0))
+		if cohort.Before(monthStart(from)) || cohort.After(to) {
+			continue
+		}
+
+		months := monthsBetween(cohort, now) + 1
+		ct, ok := cohorts[cohort]
+		if !ok {
+			ct = &cohortTally{
+				retained:    make([]int64, months),
+				retainedMRR: make([]int64, months),
+			}
+			cohorts[cohort] = ct
+		}
+		ct.customers++
+
+		for _, s := range customerSubs {
+			if monthStart(time.Unix(subscriptionStart(s), 0)).Equal(cohort) {
+				ct.startingMRR += calculateMRR(s)
+			}
+		}
+		ct.retained[0]++
+		for k := 1; k < months; k++ {
+			checkpoint := cohort.AddDate(0, k, 0).Unix()
+			var mrr int64
+			active := false
+			for _, s := range customerSubs {
+				if activeAt(s, checkpoint) {
+					active = true
+					mrr += calculateMRR(s)
+				}
+			}
+			if active {
+				ct.retained[k]++
+				ct.retainedMRR[k] += mrr
+			}
+		}
+	}
+
+	result := make([]CohortData, 0, len(cohorts))
+	for cohort, ct := range cohorts {
+		// Month 0 is the signup month, so everything starting in it is retained
+		ct.retainedMRR[0] = ct.startingMRR
+		cd := CohortData{
+			Cohort:           cohort,
+			Customers:        ct.customers,
+			StartingMRR:      ct.startingMRR,
+			LogoRetention:    make([]float64, len(ct.retained)),
+			RevenueRetention: make([]float64, len(ct.retained)),
+		}
+		for k := range ct.retained {
+			cd.LogoRetention[k] = float64(ct.retained[k]) / float64(ct.customers) * 100
+			if ct.startingMRR > 0 {
+				cd.RevenueRetention[k] = float64(ct.retainedMRR[k]) / float64(ct.startingMRR) * 100
+			}
+		}
+		result = append(result, cd)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Cohort.Before(result[j].Cohort)
+	})
+	return result
+}
+
+// paying reports whether a subscription became a paying one. Incomplete
+// subscriptions and trials that have not converted, whether still running or
+// canceled before they ended, never did.
+func paying(s *stripe.Subscription) bool {
+	switch s.Status {
+	case stripe.SubscriptionStatusIncomplete, stripe.SubscriptionStatusIncompleteExpired,
+		stripe.SubscriptionStatusTrialing:
+		return false
+	}
+	return s.TrialEnd == 0 || s.EndedAt == 0 || s.EndedAt > s.TrialEnd
+}
+
+// subscriptionStart returns when billing started, preferring start_date
+// which survives backdating
+func subscriptionStart(s *stripe.Subscription) int64 {
+	if s.StartDate > 0 {
+		return s.StartDate
+	}
+	return s.Created
+}
+
+// activeAt reports whether the subscription had started and not yet ended at t
+func activeAt(s *stripe.Subscription, t int64) bool {
+	if subscriptionStart(s) > t {
+		return false
+	}
+	return s.EndedAt == 0 || s.EndedAt > t
+}
+
+// monthStart truncates t to the first instant of its UTC month
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// monthsBetween returns the number of calendar months from a to b
+func monthsBetween(a, b time.Time) int {
+	a, b = a.UTC(), b.UTC()
+	return (b.Year()-a.Year())*12 + int(b.Month()) - int(a.Month())
+}
@@ -0,0 +1,71 @@
+package stripe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+func TestBuildCohorts(t *testing.T) {
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := func(month, d int) int64 { return jan.AddDate(0, month, d-1).Unix() }
+	sub := func(customer string, status stripe.SubscriptionStatus, start, ended, amount int64) *stripe.Subscription {
+		return &stripe.Subscription{
+			Customer:  &stripe.Customer{ID: customer},
+			Status:    status,
+			StartDate: start,
+			EndedAt:   ended,
+			Items: &stripe.SubscriptionItemList{Data: []*stripe.SubscriptionItem{{
+				Quantity: 1,
+				Price: &stripe.Price{
+					UnitAmount: amount,
+					Recurring:  &stripe.PriceRecurring{Interval: stripe.PriceRecurringIntervalMonth},
+				},
+			}}},
+		}
+	}
+
+	converted := sub("cus_converted", stripe.SubscriptionStatusActive, day(0, 20), 0, 400)
+	converted.TrialEnd = day(1, 3)
+	trialing := sub("cus_trialing", stripe.SubscriptionStatusTrialing, day(0, 25), 0, 900)
+	trialing.TrialEnd = day(3, 25)
+	abandoned := sub("cus_abandoned", stripe.SubscriptionStatusCanceled, day(0, 5), day(0, 15), 900)
+	abandoned.TrialEnd = day(0, 19)
+
+	subs := []*stripe.Subscription{
+		sub("cus_stays", stripe.SubscriptionStatusActive, day(0, 10), 0, 1000),
+		sub("cus_leaves", stripe.SubscriptionStatusCanceled, day(0, 12), day(1, 15), 600),
+		converted,
+		trialing,
+		abandoned,
+		sub("cus_incomplete", stripe.SubscriptionStatusIncompleteExpired, day(0, 3), 0, 900),
+		sub("cus_feb", stripe.SubscriptionStatusActive, day(1, 2), 0, 500),
+		sub("cus_before", stripe.SubscriptionStatusActive, day(-1, 2), 0, 500),
+	}
+
+	now := jan.AddDate(0, 2, 10)
+	cohorts := buildCohorts(subs, jan, now, now)
+	if len(cohorts) != 2 {
+		t.Fatalf("got %d cohorts, want 2: %+v", len(cohorts), cohorts)
+	}
+
+	c := cohorts[0]
+	if !c.Cohort.Equal(jan) || c.Customers != 3 || c.StartingMRR != 2000 {
+		t.Fatalf("january cohort: got %v with %d customers and %d starting MRR, want 3 customers and 2000", c.Cohort, c.Customers, c.StartingMRR)
+	}
+	wantLogo := []float64{100, 100, float64(2) / 3 * 100}
+	wantRevenue := []float64{100, 100, 70}
+	if len(c.LogoRetention) != len(wantLogo) {
+		t.Fatalf("got %d months, want %d", len(c.LogoRetention), len(wantLogo))
+	}
+	for k := range wantLogo {
+		if c.LogoRetention[k] != wantLogo[k] || c.RevenueRetention[k] != wantRevenue[k] {
+			t.Errorf("month %d: got logo %v revenue %v, want %v and %v", k, c.LogoRetention[k], c.RevenueRetention[k], wantLogo[k], wantRevenue[k])
+		}
+	}
+
+	if feb := cohorts[1]; !feb.Cohort.Equal(jan.AddDate(0, 1, 0)) || feb.Customers != 1 {
+		t.Errorf("february cohort: got %v with %d customers, want 1", feb.Cohort, feb.Customers)
+	}
+}
@@ -0,0 +1,198 @@
+package stripe
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/invoice"
+)
+
+// TopCustomer represents one customer's MRR and revenue and their share of the total
+type TopCustomer struct {
+	Customer string
+	Email    string
+	Name     string
+	MRR      int64
+	Revenue  int64   // Paid in the requested range
+	Share    float64 // % of total MRR or revenue, whichever was ranked on
+}
+
+// Concentration represents how much MRR is held by the largest customers
+type Concentration struct {
+	Customers      int64
+	Top1Share      float64 // % of MRR held by the largest customer
+	Top10Share     float64 // % of MRR held by the 10 largest customers
+	TopDecileShare float64 // % of MRR held by the largest 10% of customers
+	HHI            float64 // Herfindahl-Hirschman index of MRR shares, 0-10000
+}
+
+// GetTopCustomers returns the limit largest customers ranked by MRR, or by
+// revenue paid between from and to when byRevenue is set
+func (c *Client) GetTopCustomers(ctx context.Context, byRevenue bool, from, to time.Time, limit int64) ([]TopCustomer, error) {
+	stripe.Key = c.key
+
+	if limit <= 0 {
+		limit = 10
+	}
+
+	// Customers are expanded on the lists rather than fetched one per row
+	subs, err := c.listActiveSubscriptions(ctx, "data.customer")
+	if err != nil {
+		return nil, err
+	}
+	mrr := customerMRR(subs)
+	customers := make(map[string]*stripe.Customer)
+	for _, s := range subs {
+		if s.Customer != nil {
+			customers[s.Customer.ID] = s.Customer
+		}
+	}
+
+	revenue := make(map[string]int64)
+	if byRevenue {
+		invoices, err := c.listPaidInvoices(ctx, from, to, "data.customer")
+		if err != nil {
+			return nil, err
+		}
+		for _, inv := range invoices {
+			if inv.Customer != nil {
+				revenue[inv.Customer.ID] += inv.AmountPaid
+				customers[inv.Customer.ID] = inv.Customer
+			}
+		}
+	}
+
+	ranked := mrr
+	if byRevenue {
+		ranked = revenue
+	}
+	var total int64
+	for _, v := range ranked {
+		total += v
+	}
+
+	result := make([]TopCustomer, 0, len(ranked))
+	for id := range ranked {
+		result = append(result, TopCustomer{Customer: id, MRR: mrr[id], Revenue: revenue[id]})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return ranked[result[i].Customer] > ranked[result[j].Customer]
+	})
+	if int64(len(result)) > limit {
+		result = result[:limit]
+	}
+
+	for i := range result {
+		tc := &result[i]
+		if total > 0 {
+			tc.Share = float64(ranked[tc.Customer]) / float64(total) * 100
+		}
+		if cus := customers[tc.Customer]; cus != nil {
+			tc.Email = cus.Email
+			tc.Name = cus.Name
+		}
+	}
+	return result, nil
+}
+
+// GetConcentration returns MRR concentration across active customers
+func (c *Client) GetConcentration(ctx context.Context) (*Concentration, error) {
+	stripe.Key = c.key
+
+	subs, err := c.listActiveSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conc := calculateConcentration(customerMRR(subs))
+	return &conc, nil
+}
+
+// calculateConcentration computes top-N shares and the Herfindahl index from
+// per-customer MRR
+func calculateConcentration(mrr map[string]int64) Concentration {
+	values := make([]int64, 0, len(mrr))
+	var total int64
+	for _, v := range mrr {
+		if v > 0 {
+			values = append(values, v)
+			total += v
+		}
+	}
+	conc := Concentration{Customers: int64(len(values))}
+	if total == 0 {
+		return conc
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] > values[j] })
+
+	share := func(n int) float64 {
+		var sum int64
+		for _, v := range values[:min(n, len(values))] {
+			sum += v
+		}
+		return float64(sum) / float64(total) * 100
+	}
+	conc.Top1Share = share(1)
+	conc.Top10Share = share(10)
+	conc.TopDecileShare = share(int(math.Ceil(float64(len(values)) / 10)))
+	for _, v := range values {
+		s := float64(v) / float64(total) * 100
+		conc.HHI += s * s
+	}
+	return conc
+}
+
+// customerMRR sums subscription MRR per customer
+func customerMRR(subs []*stripe.Subscription) map[string]int64 {
+	mrr := make(map[string]int64)
+	for _, s := range subs {
+		if s.Customer != nil {
+			mrr[s.Customer.ID] += calculateMRR(s)
+		}
+	}
+	return mrr
+}
+
+// paidInvoiceLookback bounds how long before from an invoice can have been
+// created and still be paid in the range. It covers net 90 payment terms;
+// invoices paid later than that after they were created are missed.
+const paidInvoiceLookback = 90 * 24 * time.Hour
+
+// listPaidInvoices returns invoices paid between from and to, with the given
+// fields expanded. Invoices are filtered on when they were paid, so ones
+// created up to paidInvoiceLookback before from still count.
+func (c *Client) listPaidInvoices(ctx context.Context, from, to time.Time, expand ...string) ([]*stripe.Invoice, error) {
+	params := &stripe.InvoiceListParams{
+		Status: stripe.String(string(stripe.InvoiceStatusPaid)),
+		CreatedRange: &stripe.RangeQueryParams{
+			GreaterThanOrEqual: max(from.Add(-paidInvoiceLookback).Unix(), 0),
+			LesserThanOrEqual:  to.Unix(),
+		},
+	}
+	for _, f := range expand {
+		params.AddExpand(f)
+	}
+	params.Context = ctx
+
+	var invoices []*stripe.Invoice
+	iter := invoice.List(params)
+	for iter.Next() {
+		inv := iter.Invoice()
+		paidAt := invoicePaidAt(inv)
+		if paidAt >= from.Unix() && paidAt <= to.Unix() {
+			invoices = append(invoices, inv)
+		}
+	}
+	return invoices, iter.Err()
+}
+
+// invoicePaidAt returns when the invoice was paid, falling back to its
+// creation time for invoices without status transitions
+func invoicePaidAt(inv *stripe.Invoice) int64 {
+	if inv.StatusTransitions != nil && inv.StatusTransitions.PaidAt > 0 {
+		return inv.StatusTransitions.PaidAt
+	}
+	return inv.Created
+}
@@ -0,0 +1,27 @@
+package stripe
+
+import "testing"
+
+func TestCalculateConcentration(t *testing.T) {
+	conc := calculateConcentration(map[string]int64{
+		"cus_a": 5000,
+		"cus_b": 3000,
+		"cus_c": 2000,
+		// No MRR, not counted
+		"cus_d": 0,
+	})
+	if conc.Customers != 3 {
+		t.Errorf("expected 3 customers, got %d", conc.Customers)
+	}
+	if conc.Top1Share != 50 || conc.Top10Share != 100 || conc.TopDecileShare != 50 {
+		t.Errorf("unexpected shares: %+v", conc)
+	}
+	// 50² + 30² + 20²
+	if conc.HHI != 3800 {
+		t.Errorf("expected HHI 3800, got %v", conc.HHI)
+	}
+
+	if empty := calculateConcentration(nil); empty != (Concentration{}) {
+		t.Errorf("expected zero concentration without MRR, got %+v", empty)
+	}
+}
@@ -0,0 +1,213 @@
+package stripe
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/coupon"
+	"github.com/stripe/stripe-go/v82/promotioncode"
+	"github.com/stripe/stripe-go/v82/subscription"
+)
+
+// CouponData represents redemptions and the MRR discounted by one coupon or
+// promotion code. Coupon rows only count discounts applied without a code.
+type CouponData struct {
+	Coupon              string
+	Name                string
+	PromotionCode       string // Promotion code ID, empty for coupon rows
+	Code                string
+	PercentOff          float64
+	AmountOff           int64
+	Duration            string
+	Active              bool
+	TimesRedeemed       int64
+	MaxRedemptions      int64
+	ActiveSubscriptions int64 // Active subscriptions carrying the discount
+	ListMRR             int64 // MRR of those subscriptions before discounts
+	DiscountedMRR       int64
+	MRRDiscount         int64 // ListMRR minus DiscountedMRR
+}
+
+// GetCoupons returns every coupon and promotion code with the discount it
+// currently gives on active subscriptions, largest MRR discount first
+func (c *Client) GetCoupons(ctx context.Context) ([]CouponData, error) {
+	stripe.Key = c.key
+
+	rows := make(map[string]*CouponData)
+	var order []string
+
+	cParams := &stripe.CouponListParams{}
+	cParams.Context = ctx
+	cIter := coupon.List(cParams)
+	for cIter.Next() {
+		cp := cIter.Coupon()
+		row := couponRow(cp)
+		row.Active = cp.Valid
+		row.TimesRedeemed = cp.TimesRedeemed
+		row.MaxRedemptions = cp.MaxRedemptions
+		rows[cp.ID] = row
+		order = append(order, cp.ID)
+	}
+	if err := cIter.Err(); err != nil {
+		return nil, err
+	}
+
+	pParams := &stripe.PromotionCodeListParams{}
+	pParams.Context = ctx
+	pIter := promotioncode.List(pParams)
+	for pIter.Next() {
+		pc := pIter.PromotionCode()
+		row := couponRow(pc.Coupon)
+		row.PromotionCode = pc.ID
+		row.Code = pc.Code
+		row.Active = pc.Active
+		row.TimesRedeemed = pc.TimesRedeemed
+		row.MaxRedemptions = pc.MaxRedemptions
+		rows[pc.ID] = row
+		order = append(order, pc.ID)
+	}
+	if err := pIter.Err(); err != nil {
+		return nil, err
+	}
+
+	subs, err := c.listDiscountedSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for key, t := range summarizeDiscounts(subs, time.Now()) {
+		row, ok := rows[key]
+		if !ok {
+			// Deleted coupon still applied to existing subscriptions
+			row = couponRow(t.coupon)
+			rows[key] = row
+			order = append(order, key)
+		}
+		row.ActiveSubscriptions = t.subscriptions
+		row.ListMRR = t.listMRR
+		row.MRRDiscount = t.discount
+		row.DiscountedMRR = t.listMRR - t.discount
+	}
+
+	result := make([]CouponData, len(order))
+	for i, key := range order {
+		result[i] = *rows[key]
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].MRRDiscount > result[j].MRRDiscount
+	})
+	return result, nil
+}
+
+// couponRow starts a row with the terms of a coupon
+func couponRow(cp *stripe.Coupon) *CouponData {
+	if cp == nil {
+		return &CouponData{}
+	}
+	return &CouponData{
+		Coupon:     cp.ID,
+		Name:       cp.Name,
+		PercentOff: cp.PercentOff,
+		AmountOff:  cp.AmountOff,
+		Duration:   string(cp.Duration),
+	}
+}
+
+// listDiscountedSubscriptions returns active subscriptions with their
+// subscription and item level discounts expanded
+func (c *Client) listDiscountedSubscriptions(ctx context.Context) ([]*stripe.Subscription, error) {
+	params := &stripe.SubscriptionListParams{
+		Status: stripe.String("active"),
+	}
+	params.Expand = []*string{
+		stripe.String("data.discounts"),
+		stripe.String("data.items.data.price"),
+		stripe.String("data.items.data.discounts"),
+	}
+	params.Context = ctx
+
+	var subs []*stripe.Subscription
+	iter := subscription.List(params)
+	for iter.Next() {
+		subs = append(subs, iter.Subscription())
+	}
+	return subs, iter.Err()
+}
+
+// discountTally accumulates the subscriptions carrying one discount
+type discountTally struct {
+	coupon        *stripe.Coupon
+	subscriptions int64
+	listMRR       int64
+	discount      int64
+}
+
+// summarizeDiscounts groups the discounts active at now by promotion code, or
+// by coupon when applied without a code. A subscription's discounts never
+// exceed its MRR.
+func summarizeDiscounts(subs []*stripe.Subscription, now time.Time) map[string]*discountTally {
+	tallies := make(map[string]*discountTally)
+	for _, s := range subs {
+		listMRR := calculateMRR(s)
+		remaining := listMRR
+		seen := make(map[string]bool)
+
+		apply := func(d *stripe.Discount, items []*stripe.SubscriptionItem) {
+			if d == nil || d.Coupon == nil || (d.End > 0 && d.End <= now.Unix()) {
+				return
+			}
+			key := d.Coupon.ID
+			if d.PromotionCode != nil && d.PromotionCode.ID != "" {
+				key = d.PromotionCode.ID
+			}
+			t, ok := tallies[key]
+			if !ok {
+				t = &discountTally{coupon: d.Coupon}
+				tallies[key] = t
+			}
+			if !seen[key] {
+				seen[key] = true
+				t.subscriptions++
+				t.listMRR += listMRR
+			}
+			off := min(discountMRR(d.Coupon, items), remaining)
+			t.discount += off
+			remaining -= off
+		}
+
+		// Item discounts apply first, then subscription discounts to the rest
+		for _, item := range s.Items.Data {
+			for _, d := range item.Discounts {
+				apply(d, []*stripe.SubscriptionItem{item})
+			}
+		}
+		for _, d := range s.Discounts {
+			apply(d, s.Items.Data)
+		}
+	}
+	return tallies
+}
+
+// discountMRR returns the monthly amount a coupon takes off the items it
+// applies to. Fixed amounts are taken once per billing period.
+func discountMRR(cp *stripe.Coupon, items []*stripe.SubscriptionItem) int64 {
+	var mrr int64
+	var interval stripe.PriceRecurringInterval
+	for _, item := range items {
+		if cp.AppliesTo != nil && len(cp.AppliesTo.Products) > 0 &&
+			!slices.Contains(cp.AppliesTo.Products, productID(item.Price)) {
+			continue
+		}
+		mrr += itemMRR(item)
+		if interval == "" && item.Price != nil && item.Price.Recurring != nil {
+			interval = item.Price.Recurring.Interval
+		}
+	}
+
+	if cp.PercentOff > 0 {
+		return int64(float64(mrr) * cp.PercentOff / 100)
+	}
+	return min(monthlyAmount(cp.AmountOff, interval), mrr)
+}
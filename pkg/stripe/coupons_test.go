@@ -0,0 +1,69 @@
+package stripe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+func TestSummarizeDiscounts(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	item := func(product string, amount int64, interval stripe.PriceRecurringInterval, discounts ...*stripe.Discount) *stripe.SubscriptionItem {
+		return &stripe.SubscriptionItem{
+			Quantity:  1,
+			Discounts: discounts,
+			Price: &stripe.Price{
+				UnitAmount: amount,
+				Product:    &stripe.Product{ID: product},
+				Recurring:  &stripe.PriceRecurring{Interval: interval},
+			},
+		}
+	}
+	sub := func(discounts []*stripe.Discount, items ...*stripe.SubscriptionItem) *stripe.Subscription {
+		return &stripe.Subscription{
+			Discounts: discounts,
+			Items:     &stripe.SubscriptionItemList{Data: items},
+		}
+	}
+
+	half := &stripe.Coupon{ID: "half", PercentOff: 50}
+	tenOff := &stripe.Coupon{ID: "ten", AmountOff: 1000}
+	seats := &stripe.Coupon{ID: "seats", PercentOff: 100, AppliesTo: &stripe.CouponAppliesTo{Products: []string{"prod_seats"}}}
+
+	subs := []*stripe.Subscription{
+		// Coupon applied through a promotion code
+		sub([]*stripe.Discount{{Coupon: half, PromotionCode: &stripe.PromotionCode{ID: "promo_launch"}}},
+			item("prod_pro", 2000, stripe.PriceRecurringIntervalMonth)),
+		// Coupon applied directly, restricted to one product
+		sub([]*stripe.Discount{{Coupon: seats}},
+			item("prod_pro", 2000, stripe.PriceRecurringIntervalMonth),
+			item("prod_seats", 500, stripe.PriceRecurringIntervalMonth)),
+		// Fixed amount off a yearly price is spread over the year
+		sub(nil, item("prod_pro", 24000, stripe.PriceRecurringIntervalYear, &stripe.Discount{Coupon: tenOff})),
+		// Expired discount
+		sub([]*stripe.Discount{{Coupon: half, End: now.Add(-time.Hour).Unix()}},
+			item("prod_pro", 2000, stripe.PriceRecurringIntervalMonth)),
+	}
+
+	got := summarizeDiscounts(subs, now)
+	want := map[string]discountTally{
+		"promo_launch": {subscriptions: 1, listMRR: 2000, discount: 1000},
+		"seats":        {subscriptions: 1, listMRR: 2500, discount: 500},
+		"ten":          {subscriptions: 1, listMRR: 2000, discount: 83},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d codes, want %d", len(got), len(want))
+	}
+	for key, w := range want {
+		g, ok := got[key]
+		if !ok {
+			t.Errorf("%s: missing", key)
+			continue
+		}
+		if g.subscriptions != w.subscriptions || g.listMRR != w.listMRR || g.discount != w.discount {
+			t.Errorf("%s: got %d subs, list %d, discount %d; want %d, %d, %d",
+				key, g.subscriptions, g.listMRR, g.discount, w.subscriptions, w.listMRR, w.discount)
+		}
+	}
+}
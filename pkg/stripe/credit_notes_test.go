@@ -0,0 +1,57 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+func TestCreditNoteAdjustment(t *testing.T) {
+	tests := []struct {
+		name string
+		cn   *stripe.CreditNote
+		want int64
+	}{
+		{
+			name: "refunded through Stripe",
+			cn: &stripe.CreditNote{
+				Status:            stripe.CreditNoteStatusIssued,
+				PostPaymentAmount: 1000,
+				Refunds:           []*stripe.CreditNoteRefund{{AmountRefunded: 1000}},
+			},
+			want: 0,
+		},
+		{
+			name: "partly out of band",
+			cn: &stripe.CreditNote{
+				Status:            stripe.CreditNoteStatusIssued,
+				PostPaymentAmount: 1000,
+				OutOfBandAmount:   400,
+				Refunds:           []*stripe.CreditNoteRefund{{AmountRefunded: 600}},
+			},
+			want: 400,
+		},
+		{
+			name: "credited to balance",
+			cn:   &stripe.CreditNote{Status: stripe.CreditNoteStatusIssued, PostPaymentAmount: 500},
+			want: 0,
+		},
+		{
+			name: "before payment",
+			cn:   &stripe.CreditNote{Status: stripe.CreditNoteStatusIssued, PrePaymentAmount: 500},
+			want: 0,
+		},
+		{
+			name: "void",
+			cn:   &stripe.CreditNote{Status: stripe.CreditNoteStatusVoid, PostPaymentAmount: 500, OutOfBandAmount: 500},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := creditNoteAdjustment(tt.cn); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
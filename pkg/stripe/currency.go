@@ -0,0 +1,25 @@
+package stripe
+
+import "strings"
+
+// zeroDecimalCurrencies are the currencies Stripe amounts are given in whole
+// units for, rather than in cents
+var zeroDecimalCurrencies = map[string]bool{
+	"bif": true, "clp": true, "djf": true, "gnf": true, "jpy": true, "kmf": true,
+	"krw": true, "mga": true, "pyg": true, "rwf": true, "ugx": true, "vnd": true,
+	"vuv": true, "xaf": true, "xof": true, "xpf": true,
+}
+
+// ZeroDecimal reports whether amounts in the currency have no minor unit
+func ZeroDecimal(currency string) bool {
+	return zeroDecimalCurrencies[strings.ToLower(currency)]
+}
+
+// CurrencyUnits converts a Stripe amount, in the currency's smallest unit,
+// to currency units
+func CurrencyUnits(amount int64, currency string) float64 {
+	if ZeroDecimal(currency) {
+		return float64(amount)
+	}
+	return float64(amount) / 100
+}
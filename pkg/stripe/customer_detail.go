@@ -0,0 +1,241 @@
+package stripe
+
+import (
+	"context"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/charge"
+	"github.com/stripe/stripe-go/v82/dispute"
+	"github.com/stripe/stripe-go/v82/invoice"
+	"github.com/stripe/stripe-go/v82/refund"
+	"github.com/stripe/stripe-go/v82/subscription"
+)
+
+// RefundData represents refund information
+type RefundData struct {
+	ID       string
+	Charge   string
+	Amount   int64
+	Currency string
+	Status   string
+	Reason   string
+	Created  time.Time
+}
+
+// DisputeData represents dispute information
+type DisputeData struct {
+	ID       string
+	Charge   string
+	Amount   int64
+	Currency string
+	Status   string
+	Reason   string
+	Created  time.Time
+}
+
+// MRRPoint represents MRR at the start of a month
+type MRRPoint struct {
+	Time time.Time
+	MRR  int64
+}
+
+// CustomerDetail represents everything billed to one customer
+type CustomerDetail struct {
+	Subscriptions []SubscriptionData
+	Invoices      []InvoiceData
+	Charges       []ChargeData
+	Refunds       []RefundData
+	Disputes      []DisputeData
+	MRRHistory    []MRRPoint
+}
+
+// GetCustomerDetail returns subscriptions in every status, invoices, charges,
+// refunds, disputes and monthly MRR history for one customer
+func (c *Client) GetCustomerDetail(ctx context.Context, customerID string) (*CustomerDetail, error) {
+	stripe.Key = c.key
+
+	detail := &CustomerDetail{}
+
+	subParams := &stripe.SubscriptionListParams{
+		Customer: stripe.String(customerID),
+		Status:   stripe.String("all"),
+	}
+	subParams.Expand = []*string{
+		stripe.String("data.items.data.price"),
+	}
+	subParams.Context = ctx
+
+	var subs []*stripe.Subscription
+	subIter := subscription.List(subParams)
+	for subIter.Next() {
+		s := subIter.Subscription()
+		subs = append(subs, s)
+		detail.Subscriptions = append(detail.Subscriptions, toSubscriptionData(s))
+	}
+	if err := subIter.Err(); err != nil {
+		return nil, err
+	}
+	detail.MRRHistory = mrrHistory(subs, time.Now())
+
+	invParams := &stripe.InvoiceListParams{
+		Customer: stripe.String(customerID),
+	}
+	invParams.Context = ctx
+
+	var invoices []*stripe.Invoice
+	invIter := invoice.List(invParams)
+	for invIter.Next() {
+		invoices = append(invoices, invIter.Invoice())
+	}
+	if err := invIter.Err(); err != nil {
+		return nil, err
+	}
+	names, err := c.getProductNames(ctx, invoiceProductIDs(invoices))
+	if err != nil {
+		return nil, err
+	}
+	for _, inv := range invoices {
+		detail.Invoices = append(detail.Invoices, toInvoiceData(inv, names))
+	}
+
+	chParams := &stripe.ChargeListParams{
+		Customer: stripe.String(customerID),
+	}
+	chParams.Expand = []*string{
+		stripe.String("data.refunds"),
+	}
+	chParams.Context = ctx
+
+	// Disputes cannot be listed per customer, so list them once from the
+	// oldest disputed charge and keep those on this customer's charges
+	disputed := make(map[string]bool)
+	var disputedSince int64
+	chIter := charge.List(chParams)
+	for chIter.Next() {
+		ch := chIter.Charge()
+		detail.Charges = append(detail.Charges, toChargeData(ch))
+
+		if ch.AmountRefunded > 0 {
+			refunds, err := chargeRefunds(ctx, ch)
+			if err != nil {
+				return nil, err
+			}
+			detail.Refunds = append(detail.Refunds, refunds...)
+		}
+		if ch.Disputed {
+			disputed[ch.ID] = true
+			if disputedSince == 0 || ch.Created < disputedSince {
+				disputedSince = ch.Created
+			}
+		}
+	}
+	if err := chIter.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(disputed) > 0 {
+		disputes, err := listChargeDisputes(ctx, disputed, disputedSince)
+		if err != nil {
+			return nil, err
+		}
+		detail.Disputes = disputes
+	}
+
+	return detail, nil
+}
+
+// chargeRefunds returns the refunds of a charge from its expanded refund
+// list, listing them only when the charge has more than fit in it
+func chargeRefunds(ctx context.Context, ch *stripe.Charge) ([]RefundData, error) {
+	if ch.Refunds == nil || ch.Refunds.HasMore {
+		return listChargeRefunds(ctx, ch.ID)
+	}
+	refunds := make([]RefundData, 0, len(ch.Refunds.Data))
+	for _, r := range ch.Refunds.Data {
+		refunds = append(refunds, toRefundData(r, ch.ID))
+	}
+	return refunds, nil
+}
+
+func listChargeRefunds(ctx context.Context, chargeID string) ([]RefundData, error) {
+	params := &stripe.RefundListParams{
+		Charge: stripe.String(chargeID),
+	}
+	params.Context = ctx
+
+	var refunds []RefundData
+	iter := refund.List(params)
+	for iter.Next() {
+		refunds = append(refunds, toRefundData(iter.Refund(), chargeID))
+	}
+	return refunds, iter.Err()
+}
+
+func toRefundData(r *stripe.Refund, chargeID string) RefundData {
+	return RefundData{
+		ID:       r.ID,
+		Charge:   chargeID,
+		Amount:   r.Amount,
+		Currency: string(r.Currency),
+		Status:   string(r.Status),
+		Reason:   string(r.Reason),
+		Created:  time.Unix(r.Created, 0),
+	}
+}
+
+// listChargeDisputes returns the disputes created since the given time on
+// any of the given charges
+func listChargeDisputes(ctx context.Context, charges map[string]bool, since int64) ([]DisputeData, error) {
+	params := &stripe.DisputeListParams{
+		CreatedRange: &stripe.RangeQueryParams{GreaterThanOrEqual: since},
+	}
+	params.Context = ctx
+
+	var disputes []DisputeData
+	iter := dispute.List(params)
+	for iter.Next() {
+		d := iter.Dispute()
+		if d.Charge == nil || !charges[d.Charge.ID] {
+			continue
+		}
+		disputes = append(disputes, DisputeData{
+			ID:       d.ID,
+			Charge:   d.Charge.ID,
+			Amount:   d.Amount,
+			Currency: string(d.Currency),
+			Status:   string(d.Status),
+			Reason:   string(d.Reason),
+			Created:  time.Unix(d.Created, 0),
+		})
+	}
+	return disputes, iter.Err()
+}
+
+// mrrHistory returns MRR at the start of every month from the first
+// subscription until now, plus the current MRR
+func mrrHistory(subs []*stripe.Subscription, now time.Time) []MRRPoint {
+	if len(subs) == 0 {
+		return nil
+	}
+	first := subscriptionStart(subs[0])
+	for _, s := range subs[1:] {
+		first = min(first, subscriptionStart(s))
+	}
+
+	mrrAt := func(t time.Time) int64 {
+		var mrr int64
+		for _, s := range subs {
+			if payingAt(s, t.Unix()) {
+				mrr += calculateMRR(s)
+			}
+		}
+		return mrr
+	}
+
+	var points []MRRPoint
+	for m := monthStart(time.Unix(first, 0)).AddDate(0, 1, 0); m.Before(now); m = m.AddDate(0, 1, 0) {
+		points = append(points, MRRPoint{Time: m, MRR: mrrAt(m)})
+	}
+	return append(points, MRRPoint{Time: now, MRR: mrrAt(now)})
+}
@@ -0,0 +1,86 @@
+package stripe
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+func TestMRRHistory(t *testing.T) {
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := func(month, d int) int64 { return jan.AddDate(0, month, d-1).Unix() }
+	sub := func(status stripe.SubscriptionStatus, start, ended, amount int64) *stripe.Subscription {
+		return &stripe.Subscription{
+			Status:    status,
+			StartDate: start,
+			EndedAt:   ended,
+			Items: &stripe.SubscriptionItemList{Data: []*stripe.SubscriptionItem{{
+				Quantity: 1,
+				Price: &stripe.Price{
+					UnitAmount: amount,
+					Recurring:  &stripe.PriceRecurring{Interval: stripe.PriceRecurringIntervalMonth},
+				},
+			}}},
+		}
+	}
+	now := jan.AddDate(0, 4, 9)
+
+	// Upgrade: the basic plan ends mid-February and the pro plan replaces it
+	basic := sub(stripe.SubscriptionStatusCanceled, day(0, 10), day(1, 15), 1000)
+	pro := sub(stripe.SubscriptionStatusActive, day(1, 15), 0, 3000)
+	// Add-on canceled in March
+	addon := sub(stripe.SubscriptionStatusCanceled, day(0, 20), day(2, 10), 500)
+	// Trial started in March, paying from mid-April
+	trial := sub(stripe.SubscriptionStatusActive, day(2, 1), 0, 200)
+	trial.TrialEnd = day(3, 15)
+	// Trial that is still running
+	trialing := sub(stripe.SubscriptionStatusTrialing, day(3, 20), 0, 700)
+	trialing.TrialEnd = day(5, 20)
+
+	tests := []struct {
+		name string
+		subs []*stripe.Subscription
+		want []int64
+	}{
+		{"no subscriptions", nil, nil},
+		{"upgrade", []*stripe.Subscription{basic, pro}, []int64{1000, 3000, 3000, 3000, 3000}},
+		{"cancellation", []*stripe.Subscription{basic, addon}, []int64{1500, 500, 0, 0, 0}},
+		{"trials", []*stripe.Subscription{basic, trial, trialing}, []int64{1000, 0, 0, 200, 200}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mrrHistory(tt.subs, now)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d points, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, w := range tt.want {
+				if got[i].MRR != w {
+					t.Errorf("point %d at %v: got %d, want %d", i, got[i].Time, got[i].MRR, w)
+				}
+			}
+			if len(got) > 0 && !got[len(got)-1].Time.Equal(now) {
+				t.Errorf("last point at %v, want now", got[len(got)-1].Time)
+			}
+		})
+	}
+}
+
+func TestChargeRefunds(t *testing.T) {
+	ch := &stripe.Charge{
+		ID: "ch_1",
+		Refunds: &stripe.RefundList{Data: []*stripe.Refund{
+			{ID: "re_1", Amount: 300, Currency: stripe.CurrencyUSD, Status: stripe.RefundStatusSucceeded},
+			{ID: "re_2", Amount: 200, Currency: stripe.CurrencyUSD, Status: stripe.RefundStatusPending},
+		}},
+	}
+	// The expanded list is complete, so no request is made
+	refunds, err := chargeRefunds(context.Background(), ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(refunds) != 2 || refunds[0].ID != "re_1" || refunds[1].Charge != "ch_1" || refunds[1].Amount != 200 {
+		t.Errorf("got %+v", refunds)
+	}
+}
@@ -0,0 +1,184 @@
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/event"
+)
+
+// EventData represents a Stripe event and the object it describes
+type EventData struct {
+	ID         string
+	Type       string
+	Created    time.Time
+	ObjectID   string
+	ObjectType string
+	Customer   string
+	Amount     int64
+	HasAmount  bool // Amount is only set for objects that carry one
+	Currency   string
+	APIVersion string
+	Payload    json.RawMessage // The event object as sent by Stripe
+}
+
+// EventFilter selects events. Types are patterns such as "invoice.*"; an
+// event matching any of them is kept. MinAmount, in currency units, only
+// applies to events whose object has an amount.
+type EventFilter struct {
+	ListOptions
+	Types     []string
+	ObjectID  string
+	From      time.Time
+	To        time.Time
+	MinAmount float64
+}
+
+// GetEvents returns events created between filter.From and filter.To, newest
+// first, up to the filter limit. Stripe keeps events for 30 days. More are
+// reported when the limit is reached or maxScannedEvents were read.
+func (c *Client) GetEvents(ctx context.Context, filter EventFilter) ([]EventData, bool, error) {
+	stripe.Key = c.key
+
+	params := &stripe.EventListParams{
+		ListParams: filter.listParams(ctx),
+		CreatedRange: &stripe.RangeQueryParams{
+			GreaterThanOrEqual: filter.From.Unix(),
+			LesserThanOrEqual:  filter.To.Unix(),
+		},
+	}
+	setEventTypes(params, filter.Types)
+
+	var events []EventData
+	iter := &filteredIter{
+		Iter: event.List(params),
+		keep: func(e *stripe.Event) bool {
+			return filter.matches(toEventData(e))
+		},
+	}
+	hasMore, err := paginate(iter, filter.limit(), func() {
+		events = append(events, toEventData(iter.Event()))
+	})
+	return events, hasMore || iter.capped, err
+}
+
+// maxEventTypes is the number of exact types the API filters on at once
+const maxEventTypes = 20
+
+// setEventTypes lets the API filter on the event types when it can: one
+// pattern, or up to maxEventTypes types without wildcards. Other filters are
+// matched on the client.
+func setEventTypes(params *stripe.EventListParams, types []string) {
+	if len(types) == 1 {
+		params.Type = stripe.String(types[0])
+		return
+	}
+	if len(types) == 0 || len(types) > maxEventTypes {
+		return
+	}
+	for _, t := range types {
+		if strings.Contains(t, "*") {
+			return
+		}
+	}
+	params.Types = stripe.StringSlice(types)
+}
+
+// maxScannedEvents caps the events read for one query, so that filters
+// matched on the client can't walk all 30 days of events for a rare match
+const maxScannedEvents = 5000
+
+// filteredIter skips events the filter rejects so that pagination only
+// counts the ones returned. It stops after maxScannedEvents and sets capped.
+type filteredIter struct {
+	*event.Iter
+	keep    func(*stripe.Event) bool
+	scanned int
+	capped  bool
+}
+
+func (it *filteredIter) Next() bool {
+	for {
+		if it.scanned == maxScannedEvents {
+			it.capped = true
+			return false
+		}
+		if !it.Iter.Next() {
+			return false
+		}
+		it.scanned++
+		if it.keep(it.Event()) {
+			return true
+		}
+	}
+}
+
+// matches reports whether an event passes the type, object and amount filters
+func (f EventFilter) matches(e EventData) bool {
+	if len(f.Types) > 0 && !matchEventType(f.Types, e.Type) {
+		return false
+	}
+	if f.ObjectID != "" && e.ObjectID != f.ObjectID {
+		return false
+	}
+	return !e.HasAmount || CurrencyUnits(e.Amount, e.Currency) >= f.MinAmount
+}
+
+// matchEventType reports whether an event type matches any of the patterns,
+// where * stands for any run of characters
+func matchEventType(patterns []string, eventType string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, eventType); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseEventTypes splits a comma separated list of event type patterns
+func ParseEventTypes(s string) []string {
+	var types []string
+	for _, t := range strings.Split(s, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// toEventData flattens an event, reading common fields from its object
+func toEventData(e *stripe.Event) EventData {
+	data := EventData{
+		ID:         e.ID,
+		Type:       string(e.Type),
+		Created:    time.Unix(e.Created, 0),
+		APIVersion: e.APIVersion,
+	}
+	if e.Data == nil {
+		return data
+	}
+	data.Payload = e.Data.Raw
+	obj := e.Data.Object
+	data.ObjectID, _ = obj["id"].(string)
+	data.ObjectType, _ = obj["object"].(string)
+	data.Currency, _ = obj["currency"].(string)
+	// Customer is an ID unless the event expanded it
+	switch cus := obj["customer"].(type) {
+	case string:
+		data.Customer = cus
+	case map[string]interface{}:
+		data.Customer, _ = cus["id"].(string)
+	}
+	for _, key := range []string{"amount", "amount_paid", "amount_total", "amount_due"} {
+		if amount, ok := obj[key].(float64); ok {
+			data.Amount = int64(amount)
+			data.HasAmount = true
+			break
+		}
+	}
+	return data
+}
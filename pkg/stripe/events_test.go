@@ -0,0 +1,88 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+func TestEventFilterMatches(t *testing.T) {
+	filter := EventFilter{
+		Types:     ParseEventTypes("invoice.*, charge.dispute.created ,"),
+		MinAmount: 100,
+	}
+	tests := []struct {
+		event EventData
+		want  bool
+	}{
+		{EventData{Type: "invoice.payment_failed", HasAmount: true, Amount: 20000}, true},
+		{EventData{Type: "invoice.paid", HasAmount: true, Amount: 500}, false},
+		{EventData{Type: "charge.dispute.created", HasAmount: true, Amount: 10000}, true},
+		{EventData{Type: "charge.dispute.closed", HasAmount: true, Amount: 50000}, false},
+		// Zero-decimal currencies are not in cents
+		{EventData{Type: "invoice.paid", HasAmount: true, Amount: 150, Currency: "jpy"}, true},
+		{EventData{Type: "invoice.paid", HasAmount: true, Amount: 150, Currency: "usd"}, false},
+		// Events without an amount are not held to the threshold
+		{EventData{Type: "invoice.finalization_failed"}, true},
+	}
+	for _, tt := range tests {
+		if got := filter.matches(tt.event); got != tt.want {
+			t.Errorf("%s (%d): got %v, want %v", tt.event.Type, tt.event.Amount, got, tt.want)
+		}
+	}
+}
+
+func TestEventFilterObjectID(t *testing.T) {
+	filter := EventFilter{ObjectID: "sub_1"}
+	if !filter.matches(EventData{Type: "customer.subscription.updated", ObjectID: "sub_1"}) {
+		t.Error("event for sub_1 was dropped")
+	}
+	if filter.matches(EventData{Type: "customer.subscription.updated", ObjectID: "sub_2"}) {
+		t.Error("event for sub_2 was kept")
+	}
+}
+
+func TestSetEventTypes(t *testing.T) {
+	tests := []struct {
+		name      string
+		types     string
+		wantType  string
+		wantTypes int
+	}{
+		{"none", "", "", 0},
+		{"one pattern", "invoice.*", "invoice.*", 0},
+		{"exact types", "invoice.paid, charge.refunded", "", 2},
+		{"with a wildcard", "invoice.*, charge.refunded", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &stripe.EventListParams{}
+			setEventTypes(params, ParseEventTypes(tt.types))
+			if got := stripe.StringValue(params.Type); got != tt.wantType || len(params.Types) != tt.wantTypes {
+				t.Errorf("got type %q and %d types, want %q and %d", got, len(params.Types), tt.wantType, tt.wantTypes)
+			}
+		})
+	}
+}
+
+func TestToEventData(t *testing.T) {
+	e := &stripe.Event{
+		ID:   "evt_1",
+		Type: "invoice.paid",
+		Data: &stripe.EventData{Object: map[string]interface{}{
+			"id":          "in_1",
+			"object":      "invoice",
+			"customer":    "cus_1",
+			"currency":    "usd",
+			"amount_paid": float64(4200),
+			"amount_due":  float64(4200),
+		}},
+	}
+	got := toEventData(e)
+	if got.ObjectID != "in_1" || got.ObjectType != "invoice" || got.Customer != "cus_1" {
+		t.Errorf("got object %q type %q customer %q", got.ObjectID, got.ObjectType, got.Customer)
+	}
+	if !got.HasAmount || got.Amount != 4200 || got.Currency != "usd" {
+		t.Errorf("got amount %d %q (has amount %v)", got.Amount, got.Currency, got.HasAmount)
+	}
+}
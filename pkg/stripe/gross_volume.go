@@ -0,0 +1,59 @@
+package stripe
+
+import (
+	"context"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/charge"
+)
+
+// grossVolumeCurrency is the only currency counted, like the balance metrics
+const grossVolumeCurrency = "usd"
+
+// GrossVolume is the total of successful USD payments over a period, before
+// refunds and fees. It remembers the charges counted so that a charge seen
+// both in the charge list and in an event is only counted once.
+type GrossVolume struct {
+	Amount   int64
+	Payments int64
+	charges  map[string]bool
+}
+
+// GetGrossVolume returns the gross volume of charges created since the given
+// time
+func (c *Client) GetGrossVolume(ctx context.Context, since time.Time) (GrossVolume, error) {
+	stripe.Key = c.key
+
+	params := &stripe.ChargeListParams{
+		CreatedRange: &stripe.RangeQueryParams{
+			GreaterThanOrEqual: since.Unix(),
+		},
+	}
+	params.Context = ctx
+
+	var v GrossVolume
+	iter := charge.List(params)
+	for iter.Next() {
+		ch := iter.Charge()
+		if ch.Status == stripe.ChargeStatusSucceeded {
+			v.Add(ch.ID, ch.Amount, string(ch.Currency))
+		}
+	}
+	return v, iter.Err()
+}
+
+// Add counts one successful payment, skipping other currencies and charges
+// already counted. It reports whether the payment was counted.
+func (v *GrossVolume) Add(chargeID string, amount int64, currency string) bool {
+	if currency != grossVolumeCurrency || v.charges[chargeID] {
+		return false
+	}
+	if v.charges == nil {
+		v.charges = make(map[string]bool)
+	}
+	v.charges[chargeID] = true
+	v.Amount += amount
+	v.Payments++
+	return true
+}
@@ -0,0 +1,56 @@
+package stripe
+
+import (
+	"time"
+)
+
+// Bucket intervals for time series queries
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+// bucketStart truncates t to the start of its UTC day, ISO week or month
+func bucketStart(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch interval {
+	case IntervalWeek:
+		// Weeks start on Monday
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case IntervalMonth:
+		return monthStart(t)
+	}
+	return day
+}
+
+// nextBucket returns the start of the bucket following start
+func nextBucket(start time.Time, interval string) time.Time {
+	switch interval {
+	case IntervalWeek:
+		return start.AddDate(0, 0, 7)
+	case IntervalMonth:
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// buckets returns the start of every bucket overlapping [from, to]
+func buckets(from, to time.Time, interval string) []time.Time {
+	var result []time.Time
+	for b := bucketStart(from, interval); !b.After(to); b = nextBucket(b, interval) {
+		result = append(result, b)
+	}
+	return result
+}
+
+// bucketIndex maps bucket start times to their position in a series
+func bucketIndex(starts []time.Time) map[time.Time]int {
+	index := make(map[time.Time]int, len(starts))
+	for i, b := range starts {
+		index[b] = i
+	}
+	return index
+}
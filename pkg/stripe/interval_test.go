@@ -0,0 +1,54 @@
+package stripe
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBucketStart(t *testing.T) {
+	// Thursday
+	ts := time.Date(2025, 5, 15, 13, 30, 0, 0, time.UTC)
+	tests := []struct {
+		interval string
+		want     time.Time
+	}{
+		{IntervalDay, time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC)},
+		{IntervalWeek, time.Date(2025, 5, 12, 0, 0, 0, 0, time.UTC)},
+		{IntervalMonth, time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := bucketStart(ts, tt.interval); !got.Equal(tt.want) {
+			t.Errorf("bucketStart(%s) = %v, want %v", tt.interval, got, tt.want)
+		}
+	}
+
+	// Sunday belongs to the week starting the previous Monday
+	sunday := time.Date(2025, 5, 18, 23, 0, 0, 0, time.UTC)
+	if got, want := bucketStart(sunday, IntervalWeek), time.Date(2025, 5, 12, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("bucketStart(sunday) = %v, want %v", got, want)
+	}
+}
+
+func TestBuckets(t *testing.T) {
+	from := time.Date(2025, 1, 20, 12, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	got := buckets(from, to, IntervalMonth)
+	want := []time.Time{
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d buckets, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("bucket %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if n := len(buckets(from, to, IntervalDay)); n != 42 {
+		t.Errorf("got %d daily buckets, want 42", n)
+	}
+}
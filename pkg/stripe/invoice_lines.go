@@ -0,0 +1,224 @@
+package stripe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/invoice"
+	"github.com/stripe/stripe-go/v82/product"
+)
+
+// ErrLineNotFound is returned when the StartingAfter line of an invoice lines
+// query is not among the lines in range
+var ErrLineNotFound = errors.New("invoice line not found")
+
+// InvoiceLineData represents one line of an invoice
+type InvoiceLineData struct {
+	ID             string
+	Invoice        string
+	Customer       string
+	Status         string // Invoice status
+	Created        time.Time
+	ProductID      string
+	ProductName    string
+	PriceID        string
+	Description    string
+	Quantity       int64
+	Amount         int64 // Before discounts, excluding tax
+	DiscountAmount int64
+	Tax            int64
+	Currency       string
+	PeriodStart    time.Time
+	PeriodEnd      time.Time
+	Proration      bool
+}
+
+// GetInvoiceLines returns the lines of finalized invoices created between from
+// and to, newest invoice first, up to the limit in opts. Draft and void
+// invoices were never billed and are skipped.
+func (c *Client) GetInvoiceLines(ctx context.Context, from, to time.Time, opts ListOptions) ([]InvoiceLineData, bool, error) {
+	stripe.Key = c.key
+
+	params := &stripe.InvoiceListParams{
+		CreatedRange: &stripe.RangeQueryParams{
+			GreaterThanOrEqual: from.Unix(),
+			LesserThanOrEqual:  to.Unix(),
+		},
+	}
+	params.Limit = stripe.Int64(100)
+	params.Context = ctx
+
+	type invoiceLine struct {
+		inv  *stripe.Invoice
+		item *stripe.InvoiceLineItem
+	}
+
+	// Lines have no cursor across invoices, so a later page skips the lines
+	// up to and including StartingAfter
+	skipping := opts.StartingAfter != ""
+	limit := opts.limit()
+	var page []invoiceLine
+	var hasMore bool
+	iter := invoice.List(params)
+walk:
+	for iter.Next() {
+		inv := iter.Invoice()
+		if inv.Status == stripe.InvoiceStatusDraft || inv.Status == stripe.InvoiceStatusVoid {
+			continue
+		}
+		items, err := c.invoiceLines(ctx, inv)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, item := range items {
+			if skipping {
+				skipping = item.ID != opts.StartingAfter
+				continue
+			}
+			if int64(len(page)) == limit {
+				hasMore = true
+				break walk
+			}
+			page = append(page, invoiceLine{inv, item})
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, false, err
+	}
+	if skipping {
+		return nil, false, fmt.Errorf("%w: %s is not on an invoice in the time range", ErrLineNotFound, opts.StartingAfter)
+	}
+
+	var productIDs []string
+	for _, l := range page {
+		productIDs = append(productIDs, lineProductID(l.item))
+	}
+	names, err := c.getProductNames(ctx, productIDs)
+	if err != nil {
+		return nil, false, err
+	}
+
+	lines := make([]InvoiceLineData, len(page))
+	for i, l := range page {
+		lines[i] = toInvoiceLineData(l.inv, l.item, names)
+	}
+	return lines, hasMore, nil
+}
+
+// toInvoiceLineData flattens an invoice line for table output
+func toInvoiceLineData(inv *stripe.Invoice, item *stripe.InvoiceLineItem, productNames map[string]string) InvoiceLineData {
+	data := InvoiceLineData{
+		ID:             item.ID,
+		Invoice:        inv.ID,
+		Status:         string(inv.Status),
+		Created:        time.Unix(inv.Created, 0),
+		Description:    item.Description,
+		Quantity:       item.Quantity,
+		Amount:         lineAmountExcludingTax(item),
+		DiscountAmount: lineDiscount(item),
+		Currency:       string(item.Currency),
+	}
+	if inv.Customer != nil {
+		data.Customer = inv.Customer.ID
+	}
+	if item.Pricing != nil && item.Pricing.PriceDetails != nil {
+		data.PriceID = item.Pricing.PriceDetails.Price
+		data.ProductID = item.Pricing.PriceDetails.Product
+		data.ProductName = productNames[data.ProductID]
+	}
+	for _, tax := range item.Taxes {
+		data.Tax += tax.Amount
+	}
+	if item.Period != nil {
+		data.PeriodStart = time.Unix(item.Period.Start, 0)
+		data.PeriodEnd = time.Unix(item.Period.End, 0)
+	}
+	if p := item.Parent; p != nil {
+		switch {
+		case p.SubscriptionItemDetails != nil:
+			data.Proration = p.SubscriptionItemDetails.Proration
+		case p.InvoiceItemDetails != nil:
+			data.Proration = p.InvoiceItemDetails.Proration
+		}
+	}
+	return data
+}
+
+// invoiceProductNames joins the distinct product names on the lines embedded
+// in an invoice, in line order
+func invoiceProductNames(inv *stripe.Invoice, productNames map[string]string) string {
+	if inv.Lines == nil {
+		return ""
+	}
+	var names []string
+	seen := make(map[string]bool)
+	for _, item := range inv.Lines.Data {
+		name := productNames[lineProductID(item)]
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// invoiceProductIDs returns the product IDs on the lines embedded in invoices
+func invoiceProductIDs(invoices []*stripe.Invoice) []string {
+	var ids []string
+	for _, inv := range invoices {
+		if inv.Lines == nil {
+			continue
+		}
+		for _, item := range inv.Lines.Data {
+			ids = append(ids, lineProductID(item))
+		}
+	}
+	return ids
+}
+
+// lineProductID returns the product of an invoice line, or "" for lines
+// without a price
+func lineProductID(item *stripe.InvoiceLineItem) string {
+	if item.Pricing == nil || item.Pricing.PriceDetails == nil {
+		return ""
+	}
+	return item.Pricing.PriceDetails.Product
+}
+
+// getProductNames maps the given product IDs, active or archived, to their
+// names. Invoice lines only carry the product ID.
+func (c *Client) getProductNames(ctx context.Context, ids []string) (map[string]string, error) {
+	names := make(map[string]string)
+	var pending []*string
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			pending = append(pending, stripe.String(id))
+		}
+	}
+
+	// The ids filter takes one page of products per request
+	for len(pending) > 0 {
+		batch := pending[:min(len(pending), 100)]
+		pending = pending[len(batch):]
+
+		params := &stripe.ProductListParams{IDs: batch}
+		params.Limit = stripe.Int64(int64(len(batch)))
+		params.Context = ctx
+
+		iter := product.List(params)
+		for iter.Next() {
+			p := iter.Product()
+			names[p.ID] = p.Name
+		}
+		if err := iter.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,54 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+func TestToInvoiceLineData(t *testing.T) {
+	names := map[string]string{"prod_a": "Pro", "prod_b": "Seats"}
+	line := func(product string, proration bool) *stripe.InvoiceLineItem {
+		return &stripe.InvoiceLineItem{
+			Amount:          1000,
+			DiscountAmounts: []*stripe.InvoiceLineItemDiscountAmount{{Amount: 100}, {Amount: 50}},
+			Taxes:           []*stripe.InvoiceLineItemTax{{Amount: 200}},
+			Pricing: &stripe.InvoiceLineItemPricing{
+				PriceDetails: &stripe.InvoiceLineItemPricingPriceDetails{Price: "price_" + product, Product: product},
+			},
+			Parent: &stripe.InvoiceLineItemParent{
+				SubscriptionItemDetails: &stripe.InvoiceLineItemParentSubscriptionItemDetails{Proration: proration},
+			},
+		}
+	}
+	inv := &stripe.Invoice{
+		ID:       "in_1",
+		Customer: &stripe.Customer{ID: "cus_1"},
+		Lines: &stripe.InvoiceLineItemList{Data: []*stripe.InvoiceLineItem{
+			line("prod_a", false),
+			line("prod_b", true),
+			line("prod_a", true),
+		}},
+	}
+
+	got := toInvoiceLineData(inv, inv.Lines.Data[1], names)
+	if got.ProductName != "Seats" || got.PriceID != "price_prod_b" || got.Customer != "cus_1" {
+		t.Errorf("got product %q price %q customer %q", got.ProductName, got.PriceID, got.Customer)
+	}
+	if got.DiscountAmount != 150 || got.Tax != 200 || !got.Proration {
+		t.Errorf("got discount %d tax %d proration %v", got.DiscountAmount, got.Tax, got.Proration)
+	}
+
+	if got.Amount != 1000 {
+		t.Errorf("exclusive tax: got amount %d, want 1000", got.Amount)
+	}
+	inclusive := line("prod_a", false)
+	inclusive.Taxes[0].TaxBehavior = stripe.InvoiceLineItemTaxTaxBehaviorInclusive
+	if got := toInvoiceLineData(inv, inclusive, names); got.Amount != 800 || got.Tax != 200 {
+		t.Errorf("inclusive tax: got amount %d tax %d, want 800 and 200", got.Amount, got.Tax)
+	}
+
+	if got := invoiceProductNames(inv, names); got != "Pro, Seats" {
+		t.Errorf("invoiceProductNames = %q, want %q", got, "Pro, Seats")
+	}
+}
@@ -0,0 +1,91 @@
+package stripe
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/invoice"
+)
+
+// CustomerLTV represents the revenue actually collected from one customer
+type CustomerLTV struct {
+	Customer      string
+	Email         string
+	Currency      string
+	PaidInvoices  int64
+	LifetimeValue int64
+	FirstPaid     time.Time
+	LastPaid      time.Time
+}
+
+// GetCustomerLTV returns realized lifetime value per customer and currency
+// from paid invoices, highest value first. A customer billed in several
+// currencies gets one row per currency.
+func (c *Client) GetCustomerLTV(ctx context.Context) ([]CustomerLTV, error) {
+	stripe.Key = c.key
+
+	params := &stripe.InvoiceListParams{
+		Status: stripe.String(string(stripe.InvoiceStatusPaid)),
+	}
+	params.Context = ctx
+
+	var invoices []*stripe.Invoice
+	iter := invoice.List(params)
+	for iter.Next() {
+		invoices = append(invoices, iter.Invoice())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	return summarizeCustomerLTV(invoices), nil
+}
+
+// summarizeCustomerLTV totals paid invoices per customer and currency,
+// highest value first
+func summarizeCustomerLTV(invoices []*stripe.Invoice) []CustomerLTV {
+	byCustomer := make(map[string]*CustomerLTV)
+	for _, inv := range invoices {
+		if inv.Customer == nil || inv.AmountPaid == 0 {
+			continue
+		}
+		paidAt := time.Unix(invoicePaidAt(inv), 0)
+
+		key := inv.Customer.ID + "/" + string(inv.Currency)
+		cl, ok := byCustomer[key]
+		if !ok {
+			cl = &CustomerLTV{
+				Customer:  inv.Customer.ID,
+				Email:     inv.CustomerEmail,
+				Currency:  string(inv.Currency),
+				FirstPaid: paidAt,
+				LastPaid:  paidAt,
+			}
+			byCustomer[key] = cl
+		}
+		cl.PaidInvoices++
+		cl.LifetimeValue += inv.AmountPaid
+		if paidAt.Before(cl.FirstPaid) {
+			cl.FirstPaid = paidAt
+		}
+		if paidAt.After(cl.LastPaid) {
+			cl.LastPaid = paidAt
+		}
+	}
+
+	result := make([]CustomerLTV, 0, len(byCustomer))
+	for _, cl := range byCustomer {
+		result = append(result, *cl)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].LifetimeValue != result[j].LifetimeValue {
+			return result[i].LifetimeValue > result[j].LifetimeValue
+		}
+		if result[i].Customer != result[j].Customer {
+			return result[i].Customer < result[j].Customer
+		}
+		return result[i].Currency < result[j].Currency
+	})
+	return result
+}
@@ -0,0 +1,67 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+func TestMetricsLTV(t *testing.T) {
+	tests := []struct {
+		name         string
+		metrics      Metrics
+		margin       float64
+		wantLTV      int64
+		wantLifetime float64
+	}{
+		{"no churn", Metrics{ARPU: 5000, ChurnRate: 0}, 100, 0, 0},
+		{"full margin", Metrics{ARPU: 5000, ChurnRate: 5}, 100, 100000, 20},
+		{"partial margin", Metrics{ARPU: 5000, ChurnRate: 5}, 80, 80000, 20},
+		{"high churn", Metrics{ARPU: 1000, ChurnRate: 50}, 100, 2000, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.metrics.LTV(tt.margin); got != tt.wantLTV {
+				t.Errorf("LTV got %d, want %d", got, tt.wantLTV)
+			}
+			if got := tt.metrics.AvgCustomerLifetime(); got != tt.wantLifetime {
+				t.Errorf("AvgCustomerLifetime got %v, want %v", got, tt.wantLifetime)
+			}
+		})
+	}
+}
+
+func TestSummarizeCustomerLTV(t *testing.T) {
+	paid := func(customer string, currency stripe.Currency, amount, created int64) *stripe.Invoice {
+		return &stripe.Invoice{
+			Customer:   &stripe.Customer{ID: customer},
+			Currency:   currency,
+			AmountPaid: amount,
+			Created:    created,
+		}
+	}
+	got := summarizeCustomerLTV([]*stripe.Invoice{
+		paid("cus_a", stripe.CurrencyUSD, 1000, 100),
+		paid("cus_a", stripe.CurrencyEUR, 3000, 200),
+		paid("cus_a", stripe.CurrencyUSD, 1500, 300),
+		paid("cus_b", stripe.CurrencyUSD, 0, 400),
+		{AmountPaid: 500},
+	})
+
+	want := []CustomerLTV{
+		{Customer: "cus_a", Currency: "eur", PaidInvoices: 1, LifetimeValue: 3000},
+		{Customer: "cus_a", Currency: "usd", PaidInvoices: 2, LifetimeValue: 2500},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d rows, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Customer != w.Customer || g.Currency != w.Currency || g.PaidInvoices != w.PaidInvoices || g.LifetimeValue != w.LifetimeValue {
+			t.Errorf("row %d: got %+v, want %+v", i, g, w)
+		}
+	}
+	if got[1].FirstPaid.Unix() != 100 || got[1].LastPaid.Unix() != 300 {
+		t.Errorf("usd row paid from %v to %v, want 100 to 300", got[1].FirstPaid.Unix(), got[1].LastPaid.Unix())
+	}
+}
@@ -0,0 +1,71 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+const (
+	// defaultTableLimit caps table queries that do not set a limit
+	defaultTableLimit = 100
+	// maxTableLimit caps the limit a table query can set
+	maxTableLimit = 1000
+)
+
+// ListOptions bounds a table query to one page of results
+type ListOptions struct {
+	Limit int64
+	// StartingAfter is the ID of the last row of the previous page
+	StartingAfter string
+}
+
+// limit returns the requested row limit, up to maxTableLimit, or the default
+func (o ListOptions) limit() int64 {
+	if o.Limit <= 0 {
+		return defaultTableLimit
+	}
+	return min(o.Limit, maxTableLimit)
+}
+
+// listParams returns Stripe list params for the options, fetching no more
+// per request than will be returned
+func (o ListOptions) listParams(ctx context.Context) stripe.ListParams {
+	params := stripe.ListParams{
+		Limit:   stripe.Int64(min(o.limit(), 100)),
+		Context: ctx,
+	}
+	if o.StartingAfter != "" {
+		params.StartingAfter = stripe.String(o.StartingAfter)
+	}
+	return params
+}
+
+// listIter is the part of every stripe-go list iterator used for pagination
+type listIter interface {
+	Next() bool
+	Err() error
+	Meta() *stripe.ListMeta
+}
+
+// paginate calls visit for each item until limit items were visited, and
+// reports whether more items remain. Stripe is not asked for further pages
+// once the limit is reached: the current page tells whether another follows.
+func paginate(iter listIter, limit int64, visit func()) (bool, error) {
+	var n int64
+	for n < limit && iter.Next() {
+		visit()
+		n++
+	}
+	if err := iter.Err(); err != nil {
+		return false, err
+	}
+	if n < limit {
+		return false, nil
+	}
+	if meta := iter.Meta(); meta != nil && meta.HasMore {
+		return true, nil
+	}
+	// Last page: Next only looks at the items left in it
+	return iter.Next(), iter.Err()
+}
@@ -0,0 +1,95 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+// pagedIter serves n items in pages of size items, like a stripe-go iterator
+// fetching the first page when it is created
+type pagedIter struct {
+	n, size int
+	served  int // items returned by Next
+	left    int // items left in the current page
+	pages   int // pages fetched
+}
+
+func newPagedIter(n, size int) *pagedIter {
+	it := &pagedIter{n: n, size: size}
+	it.fetch()
+	return it
+}
+
+func (it *pagedIter) fetch() {
+	it.left = min(it.size, it.n-it.pages*it.size)
+	it.pages++
+}
+
+func (it *pagedIter) Next() bool {
+	if it.left == 0 {
+		if !it.Meta().HasMore {
+			return false
+		}
+		it.fetch()
+	}
+	it.left--
+	it.served++
+	return true
+}
+
+func (it *pagedIter) Err() error { return nil }
+
+func (it *pagedIter) Meta() *stripe.ListMeta {
+	return &stripe.ListMeta{HasMore: it.pages*it.size < it.n}
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    int
+		pageSize int
+		limit    int64
+		visited  int
+		hasMore  bool
+		pages    int
+	}{
+		{"fewer than limit", 3, 5, 5, 3, false, 1},
+		{"exactly limit", 5, 5, 5, 5, false, 1},
+		{"more than limit", 8, 5, 5, 5, true, 1},
+		{"limit over several pages", 250, 100, 200, 200, true, 2},
+		{"limit within the last page", 130, 100, 120, 120, true, 2},
+		{"limit at the end of the last page", 120, 100, 120, 120, false, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it := newPagedIter(tt.items, tt.pageSize)
+			visited := 0
+			hasMore, err := paginate(it, tt.limit, func() { visited++ })
+			if err != nil {
+				t.Fatal(err)
+			}
+			if visited != tt.visited || hasMore != tt.hasMore {
+				t.Errorf("expected %d visited and hasMore %v, got %d and %v", tt.visited, tt.hasMore, visited, hasMore)
+			}
+			if it.pages != tt.pages {
+				t.Errorf("fetched %d pages, want %d", it.pages, tt.pages)
+			}
+		})
+	}
+}
+
+func TestListOptionsLimit(t *testing.T) {
+	tests := []struct {
+		limit, want int64
+	}{
+		{0, defaultTableLimit},
+		{50, 50},
+		{5000, maxTableLimit},
+	}
+	for _, tt := range tests {
+		if got := (ListOptions{Limit: tt.limit}).limit(); got != tt.want {
+			t.Errorf("limit %d: got %d, want %d", tt.limit, got, tt.want)
+		}
+	}
+}
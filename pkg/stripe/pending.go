@@ -0,0 +1,76 @@
+package stripe
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+// PendingChurnData represents MRR scheduled to cancel within one month
+type PendingChurnData struct {
+	Month time.Time
+	Count int64
+	MRR   int64
+}
+
+// GetPendingChurn returns active subscriptions scheduled to cancel in the
+// future, bucketed by the month they will end. Like MRR, it leaves out
+// trialing and past due subscriptions, so the months add up to
+// Metrics.PendingChurnMRR.
+func (c *Client) GetPendingChurn(ctx context.Context) ([]PendingChurnData, error) {
+	stripe.Key = c.key
+
+	subs, err := c.listActiveSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	months := make(map[time.Time]*PendingChurnData)
+	for _, s := range subs {
+		cancelAt := pendingCancelAt(s)
+		if cancelAt <= now {
+			continue
+		}
+		month := monthStart(time.Unix(cancelAt, 0))
+		pc, ok := months[month]
+		if !ok {
+			pc = &PendingChurnData{Month: month}
+			months[month] = pc
+		}
+		pc.Count++
+		pc.MRR += calculateMRR(s)
+	}
+
+	result := make([]PendingChurnData, 0, len(months))
+	for _, pc := range months {
+		result = append(result, *pc)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Month.Before(result[j].Month)
+	})
+	return result, nil
+}
+
+// pendingCancelAt returns when a live subscription is scheduled to cancel,
+// or 0 if it is not
+func pendingCancelAt(s *stripe.Subscription) int64 {
+	if s.Status == stripe.SubscriptionStatusCanceled ||
+		s.Status == stripe.SubscriptionStatusIncompleteExpired {
+		return 0
+	}
+	if s.CancelAt > 0 {
+		return s.CancelAt
+	}
+	if s.CancelAtPeriodEnd && s.Items != nil {
+		// Billing periods live on items; the subscription ends with the latest one
+		var periodEnd int64
+		for _, item := range s.Items.Data {
+			periodEnd = max(periodEnd, item.CurrentPeriodEnd)
+		}
+		return periodEnd
+	}
+	return 0
+}
@@ -0,0 +1,32 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+func TestPendingCancelAt(t *testing.T) {
+	items := &stripe.SubscriptionItemList{Data: []*stripe.SubscriptionItem{
+		{CurrentPeriodEnd: 1000},
+		{CurrentPeriodEnd: 2000},
+	}}
+	tests := []struct {
+		name string
+		sub  *stripe.Subscription
+		want int64
+	}{
+		{"not canceling", &stripe.Subscription{Status: stripe.SubscriptionStatusActive, Items: items}, 0},
+		{"cancel at date", &stripe.Subscription{Status: stripe.SubscriptionStatusActive, CancelAt: 1500, Items: items}, 1500},
+		{"cancel at period end", &stripe.Subscription{Status: stripe.SubscriptionStatusActive, CancelAtPeriodEnd: true, Items: items}, 2000},
+		{"already canceled", &stripe.Subscription{Status: stripe.SubscriptionStatusCanceled, CancelAt: 1500, Items: items}, 0},
+		{"incomplete expired", &stripe.Subscription{Status: stripe.SubscriptionStatusIncompleteExpired, CancelAtPeriodEnd: true, Items: items}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pendingCancelAt(tt.sub); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package stripe
+
+import (
+	"context"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/quote"
+)
+
+// QuoteData represents quote information. Zero times mean the quote has not
+// reached that state.
+type QuoteData struct {
+	ID          string
+	Number      string
+	Customer    string
+	Status      string
+	Amount      int64
+	Currency    string
+	Created     time.Time
+	ExpiresAt   time.Time
+	FinalizedAt time.Time
+	AcceptedAt  time.Time
+}
+
+// QuotePipeline summarizes the quotes created over a period
+type QuotePipeline struct {
+	Quotes          []QuoteData
+	Draft           int64
+	Open            int64
+	Accepted        int64
+	Canceled        int64
+	OpenValue       int64
+	AcceptedValue   int64
+	AcceptanceRate  float64 // Accepted share of quotes accepted or canceled
+	AvgDaysToAccept float64 // From finalization to acceptance
+}
+
+// GetQuotePipeline returns the quotes created between from and to, newest
+// first, with pipeline totals
+func (c *Client) GetQuotePipeline(ctx context.Context, from, to time.Time) (*QuotePipeline, error) {
+	stripe.Key = c.key
+
+	params := &stripe.QuoteListParams{}
+	params.Context = ctx
+
+	// Quotes cannot be filtered by creation date in the API, but they are
+	// listed newest first, so stop at the first one created before from
+	var quotes []*stripe.Quote
+	iter := quote.List(params)
+	for iter.Next() {
+		q := iter.Quote()
+		if q.Created < from.Unix() {
+			break
+		}
+		if q.Created <= to.Unix() {
+			quotes = append(quotes, q)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	return buildQuotePipeline(quotes), nil
+}
+
+// buildQuotePipeline counts quotes by status and measures how many of the
+// resolved ones were accepted, and how quickly
+func buildQuotePipeline(quotes []*stripe.Quote) *QuotePipeline {
+	p := &QuotePipeline{Quotes: make([]QuoteData, 0, len(quotes))}
+	var acceptSeconds int64
+	var timed int64
+	for _, q := range quotes {
+		qd := toQuoteData(q)
+		p.Quotes = append(p.Quotes, qd)
+
+		switch q.Status {
+		case stripe.QuoteStatusDraft:
+			p.Draft++
+		case stripe.QuoteStatusOpen:
+			p.Open++
+			p.OpenValue += q.AmountTotal
+		case stripe.QuoteStatusAccepted:
+			p.Accepted++
+			p.AcceptedValue += q.AmountTotal
+			if !qd.AcceptedAt.IsZero() && !qd.FinalizedAt.IsZero() {
+				acceptSeconds += qd.AcceptedAt.Unix() - qd.FinalizedAt.Unix()
+				timed++
+			}
+		case stripe.QuoteStatusCanceled:
+			p.Canceled++
+		}
+	}
+
+	if decided := p.Accepted + p.Canceled; decided > 0 {
+		p.AcceptanceRate = float64(p.Accepted) / float64(decided) * 100
+	}
+	if timed > 0 {
+		p.AvgDaysToAccept = float64(acceptSeconds) / float64(timed) / 86400
+	}
+	return p
+}
+
+// toQuoteData flattens a quote for table output
+func toQuoteData(q *stripe.Quote) QuoteData {
+	data := QuoteData{
+		ID:       q.ID,
+		Number:   q.Number,
+		Status:   string(q.Status),
+		Amount:   q.AmountTotal,
+		Currency: string(q.Currency),
+		Created:  time.Unix(q.Created, 0),
+	}
+	if q.Customer != nil {
+		data.Customer = q.Customer.ID
+	}
+	if q.ExpiresAt > 0 {
+		data.ExpiresAt = time.Unix(q.ExpiresAt, 0)
+	}
+	if st := q.StatusTransitions; st != nil {
+		if st.FinalizedAt > 0 {
+			data.FinalizedAt = time.Unix(st.FinalizedAt, 0)
+		}
+		if st.AcceptedAt > 0 {
+			data.AcceptedAt = time.Unix(st.AcceptedAt, 0)
+		}
+	}
+	return data
+}
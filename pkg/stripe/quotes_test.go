@@ -0,0 +1,47 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+func TestBuildQuotePipeline(t *testing.T) {
+	const day = 86400
+	accepted := func(amount, finalized, days int64) *stripe.Quote {
+		return &stripe.Quote{
+			Status:      stripe.QuoteStatusAccepted,
+			AmountTotal: amount,
+			StatusTransitions: &stripe.QuoteStatusTransitions{
+				FinalizedAt: finalized,
+				AcceptedAt:  finalized + days*day,
+			},
+		}
+	}
+	quotes := []*stripe.Quote{
+		accepted(5000, 1000, 2),
+		accepted(3000, 1000, 4),
+		{Status: stripe.QuoteStatusOpen, AmountTotal: 7000},
+		{Status: stripe.QuoteStatusOpen, AmountTotal: 1000},
+		{Status: stripe.QuoteStatusCanceled, AmountTotal: 9000},
+		{Status: stripe.QuoteStatusDraft, AmountTotal: 2000},
+	}
+
+	p := buildQuotePipeline(quotes)
+	if p.Draft != 1 || p.Open != 2 || p.Accepted != 2 || p.Canceled != 1 {
+		t.Errorf("got draft %d open %d accepted %d canceled %d", p.Draft, p.Open, p.Accepted, p.Canceled)
+	}
+	if p.OpenValue != 8000 || p.AcceptedValue != 8000 {
+		t.Errorf("got open value %d accepted value %d, want 8000 and 8000", p.OpenValue, p.AcceptedValue)
+	}
+	// Open and draft quotes are not resolved yet
+	if int(p.AcceptanceRate) != 66 {
+		t.Errorf("got acceptance rate %.1f, want 66.7", p.AcceptanceRate)
+	}
+	if p.AvgDaysToAccept != 3 {
+		t.Errorf("got %.1f days to accept, want 3", p.AvgDaysToAccept)
+	}
+	if len(p.Quotes) != len(quotes) {
+		t.Errorf("got %d quote rows, want %d", len(p.Quotes), len(quotes))
+	}
+}
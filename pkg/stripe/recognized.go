@@ -0,0 +1,149 @@
+package stripe
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/invoice"
+)
+
+// RecognizedRevenuePoint represents revenue earned in one time bucket
+type RecognizedRevenuePoint struct {
+	Time       time.Time
+	Recognized int64
+	Deferred   int64 // Paid but not yet earned at the end of the bucket
+}
+
+// serviceLine is a paid invoice line and the service period it covers
+type serviceLine struct {
+	PaidAt int64
+	Start  int64
+	End    int64
+	Amount int64
+}
+
+// maxServicePeriod is the longest period an invoice line can cover, Stripe's
+// longest billing interval of three years
+const maxServicePeriod = 3 * 366 * 24 * time.Hour
+
+// GetRecognizedRevenue spreads every paid invoice line evenly over its service
+// period and returns the revenue earned in each bucket between from and to,
+// along with the deferred revenue balance. Amounts exclude tax and discounts.
+func (c *Client) GetRecognizedRevenue(ctx context.Context, from, to time.Time, interval string) ([]RecognizedRevenuePoint, error) {
+	stripe.Key = c.key
+
+	// Invoices paid before the range can still be earning revenue in it, up
+	// to the longest service period
+	invoices, err := c.listPaidInvoices(ctx, from.Add(-maxServicePeriod), to)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []serviceLine
+	for _, inv := range invoices {
+		items, err := c.invoiceLines(ctx, inv)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			sl := serviceLine{
+				PaidAt: invoicePaidAt(inv),
+				Start:  inv.Created,
+				End:    inv.Created,
+				Amount: lineNetAmount(item),
+			}
+			if item.Period != nil {
+				sl.Start, sl.End = item.Period.Start, item.Period.End
+			}
+			if sl.Amount != 0 && sl.End >= from.Unix() {
+				lines = append(lines, sl)
+			}
+		}
+	}
+	return recognizeRevenue(lines, from, to, interval), nil
+}
+
+// recognizeRevenue buckets the share of each line earned in [from, to] and the
+// balance of paid lines still to be earned at the end of every bucket
+func recognizeRevenue(lines []serviceLine, from, to time.Time, interval string) []RecognizedRevenuePoint {
+	starts := buckets(from, to, interval)
+	points := make([]RecognizedRevenuePoint, len(starts))
+	for i, b := range starts {
+		start := max(b.Unix(), from.Unix())
+		end := min(nextBucket(b, interval).Unix(), to.Unix())
+
+		var recognized, deferred float64
+		for _, l := range lines {
+			earnedByEnd := l.earnedBy(end)
+			recognized += earnedByEnd - l.earnedBy(start)
+			if l.PaidAt < end {
+				deferred += float64(l.Amount) - earnedByEnd
+			}
+		}
+		points[i] = RecognizedRevenuePoint{
+			Time:       b,
+			Recognized: int64(math.Round(recognized)),
+			Deferred:   int64(math.Round(deferred)),
+		}
+	}
+	return points
+}
+
+// earnedBy returns the part of the line earned before t. Lines without a
+// service period are earned in full at their start.
+func (l serviceLine) earnedBy(t int64) float64 {
+	switch {
+	case t <= l.Start:
+		return 0
+	case t >= l.End:
+		return float64(l.Amount)
+	}
+	return float64(l.Amount) * float64(t-l.Start) / float64(l.End-l.Start)
+}
+
+// lineNetAmount returns the line amount after discounts, excluding tax. The
+// amount of tax-inclusive prices contains the tax, which is taken out.
+func lineNetAmount(item *stripe.InvoiceLineItem) int64 {
+	return lineAmountExcludingTax(item) - lineDiscount(item)
+}
+
+// lineAmountExcludingTax returns the line amount before discounts, taking out
+// the tax included in tax-inclusive prices
+func lineAmountExcludingTax(item *stripe.InvoiceLineItem) int64 {
+	amount := item.Amount
+	for _, tax := range item.Taxes {
+		if tax.TaxBehavior == stripe.InvoiceLineItemTaxTaxBehaviorInclusive {
+			amount -= tax.Amount
+		}
+	}
+	return amount
+}
+
+// lineDiscount sums the discounts applied to an invoice line
+func lineDiscount(item *stripe.InvoiceLineItem) int64 {
+	var total int64
+	for _, d := range item.DiscountAmounts {
+		total += d.Amount
+	}
+	return total
+}
+
+// invoiceLines returns every line of an invoice, fetching the remaining pages
+// when the list embedded in the invoice is truncated
+func (c *Client) invoiceLines(ctx context.Context, inv *stripe.Invoice) ([]*stripe.InvoiceLineItem, error) {
+	if inv.Lines != nil && !inv.Lines.HasMore {
+		return inv.Lines.Data, nil
+	}
+
+	params := &stripe.InvoiceListLinesParams{Invoice: stripe.String(inv.ID)}
+	params.Context = ctx
+
+	var lines []*stripe.InvoiceLineItem
+	iter := invoice.ListLines(params)
+	for iter.Next() {
+		lines = append(lines, iter.InvoiceLineItem())
+	}
+	return lines, iter.Err()
+}
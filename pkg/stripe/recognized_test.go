@@ -0,0 +1,59 @@
+package stripe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+func TestRecognizeRevenue(t *testing.T) {
+	day := func(d int) int64 {
+		return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC).Unix()
+	}
+	from := time.Unix(day(1), 0).UTC()
+	to := time.Unix(day(5), 0).UTC()
+
+	lines := []serviceLine{
+		// 400 paid up front for days 1-4
+		{PaidAt: day(1), Start: day(1), End: day(5), Amount: 400},
+		// One-off charge on day 2
+		{PaidAt: day(2), Start: day(2), End: day(2), Amount: 50},
+		// Billed in arrears on day 4 for days 2-3
+		{PaidAt: day(4), Start: day(2), End: day(4), Amount: 200},
+	}
+
+	got := recognizeRevenue(lines, from, to, IntervalDay)
+	want := []RecognizedRevenuePoint{
+		{Recognized: 100, Deferred: 300},
+		{Recognized: 250, Deferred: 200},
+		{Recognized: 200, Deferred: 100},
+		{Recognized: 100, Deferred: 0},
+		{Recognized: 0, Deferred: 0},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d points, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		if got[i].Recognized != w.Recognized || got[i].Deferred != w.Deferred {
+			t.Errorf("day %d: got recognized %d deferred %d, want %d and %d",
+				i+1, got[i].Recognized, got[i].Deferred, w.Recognized, w.Deferred)
+		}
+	}
+}
+
+func TestLineNetAmount(t *testing.T) {
+	line := func(behavior stripe.InvoiceLineItemTaxTaxBehavior) *stripe.InvoiceLineItem {
+		return &stripe.InvoiceLineItem{
+			Amount:          1200,
+			DiscountAmounts: []*stripe.InvoiceLineItemDiscountAmount{{Amount: 100}},
+			Taxes:           []*stripe.InvoiceLineItemTax{{Amount: 200, TaxBehavior: behavior}},
+		}
+	}
+	if got := lineNetAmount(line(stripe.InvoiceLineItemTaxTaxBehaviorExclusive)); got != 1100 {
+		t.Errorf("exclusive tax: got %d, want 1100", got)
+	}
+	if got := lineNetAmount(line(stripe.InvoiceLineItemTaxTaxBehaviorInclusive)); got != 900 {
+		t.Errorf("inclusive tax: got %d, want 900", got)
+	}
+}
@@ -0,0 +1,111 @@
+package stripe
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+// RevenueRetention represents net and gross revenue retention over one period
+type RevenueRetention struct {
+	Group       string // Product ID, or empty when not grouped
+	StartingMRR int64  // MRR of customers existing at period start
+	EndingMRR   int64  // MRR of the same customers at period end
+	RetainedMRR int64  // Ending MRR capped at each customer's starting MRR
+	NRR         float64
+	GRR         float64
+}
+
+// GetRevenueRetention compares per-customer MRR at end and at the given number
+// of months before end. Customers acquired during the period are excluded.
+// When byProduct is set, retention is computed per product instead of per account.
+func (c *Client) GetRevenueRetention(ctx context.Context, end time.Time, months int, byProduct bool) ([]RevenueRetention, error) {
+	stripe.Key = c.key
+
+	subs, err := c.listAllSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if now := time.Now(); end.After(now) {
+		end = now
+	}
+	start := end.AddDate(0, -months, 0)
+	return calculateRevenueRetention(subs, start, end, byProduct), nil
+}
+
+// calculateRevenueRetention sums per-customer MRR at start and end. Prices are
+// the ones currently on each subscription, so in-place upgrades are only seen
+// when they created a new subscription.
+func calculateRevenueRetention(subs []*stripe.Subscription, start, end time.Time, byProduct bool) []RevenueRetention {
+	type key struct {
+		group    string
+		customer string
+	}
+	startMRR := make(map[key]int64)
+	endMRR := make(map[key]int64)
+
+	for _, s := range subs {
+		if s.Customer == nil {
+			continue
+		}
+		// Trials and incomplete subscriptions bring in no MRR, as in churn
+		atStart := payingAt(s, start.Unix())
+		atEnd := payingAt(s, end.Unix())
+		if !atStart && !atEnd {
+			continue
+		}
+		for _, item := range s.Items.Data {
+			k := key{customer: s.Customer.ID}
+			if byProduct {
+				k.group = productID(item.Price)
+			}
+			mrr := itemMRR(item)
+			if atStart {
+				startMRR[k] += mrr
+			}
+			if atEnd {
+				endMRR[k] += mrr
+			}
+		}
+	}
+
+	groups := make(map[string]*RevenueRetention)
+	for k, starting := range startMRR {
+		if starting == 0 {
+			continue
+		}
+		r, ok := groups[k.group]
+		if !ok {
+			r = &RevenueRetention{Group: k.group}
+			groups[k.group] = r
+		}
+		ending := endMRR[k]
+		r.StartingMRR += starting
+		r.EndingMRR += ending
+		r.RetainedMRR += min(ending, starting)
+	}
+
+	result := make([]RevenueRetention, 0, len(groups))
+	for _, r := range groups {
+		r.NRR = float64(r.EndingMRR) / float64(r.StartingMRR) * 100
+		r.GRR = float64(r.RetainedMRR) / float64(r.StartingMRR) * 100
+		result = append(result, *r)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StartingMRR > result[j].StartingMRR
+	})
+	return result
+}
+
+// productID returns the product of a price, falling back to the price ID
+func productID(p *stripe.Price) string {
+	if p == nil {
+		return ""
+	}
+	if p.Product != nil && p.Product.ID != "" {
+		return p.Product.ID
+	}
+	return p.ID
+}
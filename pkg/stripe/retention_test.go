@@ -0,0 +1,63 @@
+package stripe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+func TestCalculateRevenueRetention(t *testing.T) {
+	end := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	start := end.AddDate(-1, 0, 0)
+	before := start.AddDate(0, -1, 0).Unix()
+	during := start.AddDate(0, 6, 0).Unix()
+
+	monthly := func(amount int64) *stripe.SubscriptionItemList {
+		return &stripe.SubscriptionItemList{Data: []*stripe.SubscriptionItem{{
+			Quantity: 1,
+			Price: &stripe.Price{
+				UnitAmount: amount,
+				Recurring:  &stripe.PriceRecurring{Interval: stripe.PriceRecurringIntervalMonth},
+			},
+		}}}
+	}
+	sub := func(customer string, created, ended int64, amount int64) *stripe.Subscription {
+		return &stripe.Subscription{
+			Customer: &stripe.Customer{ID: customer},
+			Created:  created,
+			EndedAt:  ended,
+			Items:    monthly(amount),
+		}
+	}
+
+	subs := []*stripe.Subscription{
+		// Retained and expanded with a second subscription
+		sub("cus_a", before, 0, 1000),
+		sub("cus_a", during, 0, 500),
+		// Churned
+		sub("cus_b", before, during, 1000),
+		// New during the period, excluded
+		sub("cus_c", during, 0, 5000),
+	}
+	// In trial at the start, so not a paying customer yet
+	trialAtStart := sub("cus_d", before, 0, 800)
+	trialAtStart.TrialEnd = during
+	// Add-on still in trial at the end
+	trialAtEnd := sub("cus_a", during, 0, 700)
+	trialAtEnd.Status = stripe.SubscriptionStatusTrialing
+	trialAtEnd.TrialEnd = end.AddDate(0, 1, 0).Unix()
+	subs = append(subs, trialAtStart, trialAtEnd)
+
+	result := calculateRevenueRetention(subs, start, end, false)
+	if len(result) != 1 {
+		t.Fatalf("expected a single account-wide row, got %d", len(result))
+	}
+	r := result[0]
+	if r.StartingMRR != 2000 || r.EndingMRR != 1500 || r.RetainedMRR != 1000 {
+		t.Errorf("unexpected MRR: %+v", r)
+	}
+	if r.NRR != 75 || r.GRR != 50 {
+		t.Errorf("expected NRR 75 and GRR 50, got %v and %v", r.NRR, r.GRR)
+	}
+}
@@ -0,0 +1,147 @@
+package stripe
+
+import (
+	"context"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/charge"
+	"github.com/stripe/stripe-go/v82/refund"
+)
+
+// RevenuePoint represents revenue collected in one time bucket
+type RevenuePoint struct {
+	Time     time.Time
+	Paid     int64 // Paid invoices and successful charges without an invoice
+	Tax      int64 // Included in Paid
+	Refunded int64
+	Credited int64 // Credit notes on paid invoices refunded out of band
+	Net      int64
+}
+
+// NetExcludingTax returns net revenue less the tax collected on paid invoices.
+// Refunds and credit notes are taken in full, including any tax they returned.
+func (p RevenuePoint) NetExcludingTax() int64 {
+	return p.Net - p.Tax
+}
+
+// GetRevenueSeries returns paid, refunded, credited and net revenue between
+// from and to bucketed by interval. Invoices count when they were paid;
+// charges that paid no invoice, refunds and credit notes when they were
+// created. Refunds of either kind of payment are subtracted.
+func (c *Client) GetRevenueSeries(ctx context.Context, from, to time.Time, interval string) ([]RevenuePoint, error) {
+	stripe.Key = c.key
+
+	starts := buckets(from, to, interval)
+	index := bucketIndex(starts)
+	points := make([]RevenuePoint, len(starts))
+	for i, b := range starts {
+		points[i].Time = b
+	}
+
+	invoices, err := c.listPaidInvoices(ctx, from, to, "data.payments")
+	if err != nil {
+		return nil, err
+	}
+	for _, inv := range invoices {
+		if i, ok := index[bucketStart(time.Unix(invoicePaidAt(inv), 0), interval)]; ok {
+			points[i].Paid += inv.AmountPaid
+			points[i].Tax += invoiceTax(inv)
+		}
+	}
+
+	// One-off charges are revenue too, and their refunds are in the refund
+	// list below
+	invoicePayments := invoicePaymentIDs(invoices)
+	chParams := &stripe.ChargeListParams{
+		CreatedRange: &stripe.RangeQueryParams{
+			GreaterThanOrEqual: from.Unix(),
+			LesserThanOrEqual:  to.Unix(),
+		},
+	}
+	chParams.Context = ctx
+
+	chIter := charge.List(chParams)
+	for chIter.Next() {
+		ch := chIter.Charge()
+		if ch.Status != stripe.ChargeStatusSucceeded || paysInvoice(ch, invoicePayments) {
+			continue
+		}
+		if i, ok := index[bucketStart(time.Unix(ch.Created, 0), interval)]; ok {
+			points[i].Paid += ch.Amount
+		}
+	}
+	if err := chIter.Err(); err != nil {
+		return nil, err
+	}
+
+	params := &stripe.RefundListParams{
+		CreatedRange: &stripe.RangeQueryParams{
+			GreaterThanOrEqual: from.Unix(),
+			LesserThanOrEqual:  to.Unix(),
+		},
+	}
+	params.Context = ctx
+
+	iter := refund.List(params)
+	for iter.Next() {
+		r := iter.Refund()
+		if r.Status != stripe.RefundStatusSucceeded {
+			continue
+		}
+		if i, ok := index[bucketStart(time.Unix(r.Created, 0), interval)]; ok {
+			points[i].Refunded += r.Amount
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	notes, err := c.listCreditNotes(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, cn := range notes {
+		if i, ok := index[bucketStart(time.Unix(cn.Created, 0), interval)]; ok {
+			points[i].Credited += creditNoteAdjustment(cn)
+		}
+	}
+
+	for i := range points {
+		points[i].Net = points[i].Paid - points[i].Refunded - points[i].Credited
+	}
+	return points, nil
+}
+
+// invoicePaymentIDs returns the IDs of the payment intents and charges that
+// paid the given invoices. Invoices must be listed with data.payments
+// expanded.
+func invoicePaymentIDs(invoices []*stripe.Invoice) map[string]bool {
+	ids := make(map[string]bool)
+	for _, inv := range invoices {
+		if inv.Payments == nil {
+			continue
+		}
+		for _, p := range inv.Payments.Data {
+			if p.Payment == nil {
+				continue
+			}
+			if p.Payment.PaymentIntent != nil {
+				ids[p.Payment.PaymentIntent.ID] = true
+			}
+			if p.Payment.Charge != nil {
+				ids[p.Payment.Charge.ID] = true
+			}
+		}
+	}
+	return ids
+}
+
+// paysInvoice reports whether a charge, or its payment intent, paid one of
+// the invoices behind invoicePayments
+func paysInvoice(ch *stripe.Charge, invoicePayments map[string]bool) bool {
+	if invoicePayments[ch.ID] {
+		return true
+	}
+	return ch.PaymentIntent != nil && invoicePayments[ch.PaymentIntent.ID]
+}
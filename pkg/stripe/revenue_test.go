@@ -0,0 +1,35 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+func TestPaysInvoice(t *testing.T) {
+	invoices := []*stripe.Invoice{{
+		Payments: &stripe.InvoicePaymentList{Data: []*stripe.InvoicePayment{
+			{Payment: &stripe.InvoicePaymentPayment{PaymentIntent: &stripe.PaymentIntent{ID: "pi_invoice"}}},
+			{Payment: &stripe.InvoicePaymentPayment{Charge: &stripe.Charge{ID: "ch_legacy"}}},
+		}},
+	}}
+	payments := invoicePaymentIDs(invoices)
+
+	tests := []struct {
+		name string
+		ch   *stripe.Charge
+		want bool
+	}{
+		{"by payment intent", &stripe.Charge{ID: "ch_1", PaymentIntent: &stripe.PaymentIntent{ID: "pi_invoice"}}, true},
+		{"by charge", &stripe.Charge{ID: "ch_legacy"}, true},
+		{"one-off", &stripe.Charge{ID: "ch_2", PaymentIntent: &stripe.PaymentIntent{ID: "pi_other"}}, false},
+		{"no payment intent", &stripe.Charge{ID: "ch_3"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := paysInvoice(tt.ch, payments); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package stripe
+
+import (
+	"context"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/subscriptionschedule"
+)
+
+// SchedulePhaseData represents one phase of a subscription schedule
+type SchedulePhaseData struct {
+	Schedule     string
+	Customer     string
+	Subscription string
+	Status       string
+	EndBehavior  string
+	Phase        int64 // 1-based position in the schedule
+	Current      bool
+	Start        time.Time
+	End          time.Time // Zero for an open-ended last phase
+	TrialEnd     time.Time
+	MRR          int64
+}
+
+// ContractedMRRPoint represents MRR already contracted for one future time
+type ContractedMRRPoint struct {
+	Time time.Time
+	MRR  int64
+}
+
+// GetSubscriptionSchedules returns every phase of the schedules that are
+// active or not started yet
+func (c *Client) GetSubscriptionSchedules(ctx context.Context) ([]SchedulePhaseData, error) {
+	stripe.Key = c.key
+
+	schedules, err := c.listOpenSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var phases []SchedulePhaseData
+	for _, sched := range schedules {
+		for i, p := range sched.Phases {
+			pd := SchedulePhaseData{
+				Schedule:    sched.ID,
+				Status:      string(sched.Status),
+				EndBehavior: string(sched.EndBehavior),
+				Phase:       int64(i + 1),
+				Start:       time.Unix(p.StartDate, 0),
+				MRR:         phaseMRR(p),
+			}
+			if sched.Customer != nil {
+				pd.Customer = sched.Customer.ID
+			}
+			if sched.Subscription != nil {
+				pd.Subscription = sched.Subscription.ID
+			}
+			if cp := sched.CurrentPhase; cp != nil {
+				pd.Current = cp.StartDate == p.StartDate
+			}
+			if p.EndDate > 0 {
+				pd.End = time.Unix(p.EndDate, 0)
+			}
+			if p.TrialEnd > 0 {
+				pd.TrialEnd = time.Unix(p.TrialEnd, 0)
+			}
+			phases = append(phases, pd)
+		}
+	}
+	return phases, nil
+}
+
+// GetContractedMRR projects MRR at the start of every bucket between from and
+// to, starting no earlier than now. Scheduled phase changes, pending
+// cancellations and trial ends are applied, and schedules that have not
+// started yet are added. Trials are assumed to convert.
+func (c *Client) GetContractedMRR(ctx context.Context, from, to time.Time, interval string) ([]ContractedMRRPoint, error) {
+	stripe.Key = c.key
+
+	subs, err := c.listAllSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := c.listOpenSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if now := time.Now(); from.Before(now) {
+		from = now
+	}
+	starts := buckets(from, to, interval)
+	points := make([]ContractedMRRPoint, len(starts))
+	for i, b := range starts {
+		// The first bucket usually starts in the past, so project it at from
+		at := b
+		if at.Before(from) {
+			at = from
+		}
+		points[i] = ContractedMRRPoint{Time: b, MRR: contractedMRR(subs, schedules, at.Unix())}
+	}
+	return points, nil
+}
+
+// listOpenSchedules returns schedules that are active or not started yet,
+// with phase prices expanded
+func (c *Client) listOpenSchedules(ctx context.Context) ([]*stripe.SubscriptionSchedule, error) {
+	params := &stripe.SubscriptionScheduleListParams{}
+	params.Expand = []*string{
+		stripe.String("data.phases.items.price"),
+	}
+	params.Context = ctx
+
+	var schedules []*stripe.SubscriptionSchedule
+	iter := subscriptionschedule.List(params)
+	for iter.Next() {
+		sched := iter.SubscriptionSchedule()
+		if sched.Status == stripe.SubscriptionScheduleStatusActive ||
+			sched.Status == stripe.SubscriptionScheduleStatusNotStarted {
+			schedules = append(schedules, sched)
+		}
+	}
+	return schedules, iter.Err()
+}
+
+// contractedMRR returns the MRR expected at t from active and trialing
+// subscriptions and open schedules
+func contractedMRR(subs []*stripe.Subscription, schedules []*stripe.SubscriptionSchedule, t int64) int64 {
+	bySubscription := make(map[string]*stripe.SubscriptionSchedule)
+	var total int64
+	for _, sched := range schedules {
+		if sched.Subscription != nil && sched.Subscription.ID != "" {
+			bySubscription[sched.Subscription.ID] = sched
+			continue
+		}
+		// Not started yet, so there is no subscription to follow
+		if mrr, ok := scheduledMRR(sched, t); ok {
+			total += mrr
+		}
+	}
+
+	for _, s := range subs {
+		// Past due subscriptions are left out, as in MRR; trials count from
+		// their end
+		switch s.Status {
+		case stripe.SubscriptionStatusActive, stripe.SubscriptionStatusTrialing:
+		default:
+			continue
+		}
+		if cancelAt := pendingCancelAt(s); cancelAt > 0 && cancelAt <= t {
+			continue
+		}
+
+		// Schedule phases carry their own prices and trials
+		if sched, ok := bySubscription[s.ID]; ok {
+			if mrr, ok := scheduledMRR(sched, t); ok {
+				total += mrr
+			}
+			continue
+		}
+		if s.TrialEnd > t {
+			continue
+		}
+		total += calculateMRR(s)
+	}
+	return total
+}
+
+// scheduledMRR returns the MRR of the schedule phase running at t. It reports
+// false when the schedule has not started or has canceled the subscription.
+func scheduledMRR(sched *stripe.SubscriptionSchedule, t int64) (int64, bool) {
+	if len(sched.Phases) == 0 || t < sched.Phases[0].StartDate {
+		return 0, false
+	}
+	if p := phaseAt(sched, t); p != nil {
+		if p.TrialEnd > t {
+			return 0, true
+		}
+		return phaseMRR(p), true
+	}
+	// Past the last phase
+	if sched.EndBehavior == stripe.SubscriptionScheduleEndBehaviorCancel {
+		return 0, false
+	}
+	return phaseMRR(sched.Phases[len(sched.Phases)-1]), true
+}
+
+// phaseAt returns the schedule phase running at t, or nil
+func phaseAt(sched *stripe.SubscriptionSchedule, t int64) *stripe.SubscriptionSchedulePhase {
+	for _, p := range sched.Phases {
+		if p.StartDate <= t && (p.EndDate == 0 || t < p.EndDate) {
+			return p
+		}
+	}
+	return nil
+}
+
+// phaseMRR sums the monthly recurring amount of a phase's items
+func phaseMRR(p *stripe.SubscriptionSchedulePhase) int64 {
+	var mrr int64
+	for _, item := range p.Items {
+		if item.Price == nil || item.Price.Recurring == nil {
+			continue
+		}
+		mrr += monthlyAmount(item.Price.UnitAmount*item.Quantity, item.Price.Recurring.Interval)
+	}
+	return mrr
+}
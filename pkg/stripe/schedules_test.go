@@ -0,0 +1,83 @@
+package stripe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+func TestContractedMRR(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	month := func(n int) int64 { return now.AddDate(0, n, 0).Unix() }
+	price := func(amount int64) *stripe.Price {
+		return &stripe.Price{
+			UnitAmount: amount,
+			Recurring:  &stripe.PriceRecurring{Interval: stripe.PriceRecurringIntervalMonth},
+		}
+	}
+	sub := func(id string, status stripe.SubscriptionStatus, amount int64) *stripe.Subscription {
+		return &stripe.Subscription{
+			ID:     id,
+			Status: status,
+			Items:  &stripe.SubscriptionItemList{Data: []*stripe.SubscriptionItem{{Quantity: 1, Price: price(amount)}}},
+		}
+	}
+	phase := func(start, end int64, amount int64) *stripe.SubscriptionSchedulePhase {
+		return &stripe.SubscriptionSchedulePhase{
+			StartDate: start,
+			EndDate:   end,
+			Items:     []*stripe.SubscriptionSchedulePhaseItem{{Quantity: 1, Price: price(amount)}},
+		}
+	}
+
+	steady := sub("sub_steady", stripe.SubscriptionStatusActive, 1000)
+	canceling := sub("sub_canceling", stripe.SubscriptionStatusActive, 500)
+	canceling.CancelAt = month(2)
+	trial := sub("sub_trial", stripe.SubscriptionStatusTrialing, 300)
+	trial.TrialEnd = month(1)
+	pastDue := sub("sub_past_due", stripe.SubscriptionStatusPastDue, 800)
+	upgrading := sub("sub_upgrading", stripe.SubscriptionStatusActive, 2000)
+	ended := sub("sub_ended", stripe.SubscriptionStatusCanceled, 9000)
+	scheduledTrial := sub("sub_scheduled_trial", stripe.SubscriptionStatusTrialing, 600)
+	trialPhase := phase(month(-1), 0, 600)
+	trialPhase.TrialEnd = month(2)
+
+	schedules := []*stripe.SubscriptionSchedule{
+		{
+			// Steps up after three months, then keeps the last phase
+			Subscription: &stripe.Subscription{ID: "sub_upgrading"},
+			EndBehavior:  stripe.SubscriptionScheduleEndBehaviorRelease,
+			Phases:       []*stripe.SubscriptionSchedulePhase{phase(month(-1), month(3), 2000), phase(month(3), month(6), 4000)},
+		},
+		{
+			// Signed deal starting next month for one year
+			EndBehavior: stripe.SubscriptionScheduleEndBehaviorCancel,
+			Phases:      []*stripe.SubscriptionSchedulePhase{phase(month(1), month(13), 700)},
+		},
+		{
+			// Trial set by the schedule phase, ending in two months
+			Subscription: &stripe.Subscription{ID: "sub_scheduled_trial"},
+			EndBehavior:  stripe.SubscriptionScheduleEndBehaviorRelease,
+			Phases:       []*stripe.SubscriptionSchedulePhase{trialPhase},
+		},
+	}
+	subs := []*stripe.Subscription{steady, canceling, trial, pastDue, upgrading, ended, scheduledTrial}
+
+	tests := []struct {
+		months int
+		want   int64
+	}{
+		{0, 1000 + 500 + 2000},
+		{1, 1000 + 500 + 300 + 2000 + 700},
+		{2, 1000 + 300 + 2000 + 700 + 600},
+		{3, 1000 + 300 + 4000 + 700 + 600},
+		{7, 1000 + 300 + 4000 + 700 + 600},
+		{13, 1000 + 300 + 4000 + 600},
+	}
+	for _, tt := range tests {
+		if got := contractedMRR(subs, schedules, month(tt.months)); got != tt.want {
+			t.Errorf("month %d: got %d, want %d", tt.months, got, tt.want)
+		}
+	}
+}
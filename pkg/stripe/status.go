@@ -0,0 +1,70 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+// subscriptionStatuses is the display order of the status breakdown
+var subscriptionStatuses = []stripe.SubscriptionStatus{
+	stripe.SubscriptionStatusActive,
+	stripe.SubscriptionStatusTrialing,
+	stripe.SubscriptionStatusPastDue,
+	stripe.SubscriptionStatusUnpaid,
+	stripe.SubscriptionStatusIncomplete,
+	stripe.SubscriptionStatusIncompleteExpired,
+	stripe.SubscriptionStatusPaused,
+	stripe.SubscriptionStatusCanceled,
+}
+
+// SubscriptionStatusData represents subscription count and MRR for one status.
+// MRR is 0 for ended subscriptions, which no longer bring in revenue.
+type SubscriptionStatusData struct {
+	Status string
+	Count  int64
+	MRR    int64
+}
+
+// GetSubscriptionStatusBreakdown returns counts and MRR for every subscription
+// status, walking all subscriptions once
+func (c *Client) GetSubscriptionStatusBreakdown(ctx context.Context) ([]SubscriptionStatusData, error) {
+	stripe.Key = c.key
+
+	subs, err := c.listAllSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeStatuses(subs), nil
+}
+
+// summarizeStatuses groups subscriptions by status, keeping every known
+// status even when it has no subscriptions
+func summarizeStatuses(subs []*stripe.Subscription) []SubscriptionStatusData {
+	result := make([]SubscriptionStatusData, len(subscriptionStatuses))
+	index := make(map[stripe.SubscriptionStatus]int, len(subscriptionStatuses))
+	for i, status := range subscriptionStatuses {
+		result[i] = SubscriptionStatusData{Status: string(status)}
+		index[status] = i
+	}
+
+	for _, s := range subs {
+		i, ok := index[s.Status]
+		if !ok {
+			// Status added by Stripe after this list was written
+			i = len(result)
+			index[s.Status] = i
+			result = append(result, SubscriptionStatusData{Status: string(s.Status)})
+		}
+		result[i].Count++
+		if !endedStatus(s.Status) {
+			result[i].MRR += calculateMRR(s)
+		}
+	}
+	return result
+}
+
+// endedStatus reports whether a subscription status is terminal
+func endedStatus(status stripe.SubscriptionStatus) bool {
+	return status == stripe.SubscriptionStatusCanceled || status == stripe.SubscriptionStatusIncompleteExpired
+}
@@ -0,0 +1,49 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+func TestSummarizeStatuses(t *testing.T) {
+	sub := func(status stripe.SubscriptionStatus, amount int64) *stripe.Subscription {
+		return &stripe.Subscription{
+			Status: status,
+			Items: &stripe.SubscriptionItemList{Data: []*stripe.SubscriptionItem{{
+				Quantity: 1,
+				Price: &stripe.Price{
+					UnitAmount: amount,
+					Recurring:  &stripe.PriceRecurring{Interval: stripe.PriceRecurringIntervalMonth},
+				},
+			}}},
+		}
+	}
+	got := summarizeStatuses([]*stripe.Subscription{
+		sub(stripe.SubscriptionStatusActive, 1000),
+		sub(stripe.SubscriptionStatusActive, 500),
+		sub(stripe.SubscriptionStatusPastDue, 300),
+		sub(stripe.SubscriptionStatusCanceled, 9000),
+		sub(stripe.SubscriptionStatusIncompleteExpired, 700),
+	})
+
+	want := map[string]SubscriptionStatusData{
+		"active":             {Count: 2, MRR: 1500},
+		"past_due":           {Count: 1, MRR: 300},
+		"trialing":           {Count: 0, MRR: 0},
+		"canceled":           {Count: 1, MRR: 0},
+		"incomplete_expired": {Count: 1, MRR: 0},
+	}
+	if len(got) != len(subscriptionStatuses) {
+		t.Fatalf("got %d statuses, want %d", len(got), len(subscriptionStatuses))
+	}
+	for _, g := range got {
+		w, ok := want[g.Status]
+		if !ok {
+			continue
+		}
+		if g.Count != w.Count || g.MRR != w.MRR {
+			t.Errorf("%s: got count %d MRR %d, want count %d MRR %d", g.Status, g.Count, g.MRR, w.Count, w.MRR)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package stripe
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/taxrate"
+)
+
+// TaxData represents tax collected at one rate in one time bucket
+type TaxData struct {
+	Time          time.Time
+	TaxRate       string // Tax rate ID, empty for taxes without a rate
+	DisplayName   string
+	Jurisdiction  string
+	Country       string
+	State         string
+	Percentage    float64
+	Inclusive     bool
+	TaxableAmount int64
+	Tax           int64
+}
+
+// GetTaxCollected sums the taxes on invoices paid between from and to per
+// bucket and tax rate. Both Stripe Tax and manual tax rates are included.
+func (c *Client) GetTaxCollected(ctx context.Context, from, to time.Time, interval string) ([]TaxData, error) {
+	stripe.Key = c.key
+
+	invoices, err := c.listPaidInvoices(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invoices only carry the tax rate ID, so fetch each rate once
+	rates := make(map[string]*stripe.TaxRate)
+	for _, inv := range invoices {
+		for _, tax := range inv.TotalTaxes {
+			if tax.TaxRateDetails == nil || tax.TaxRateDetails.TaxRate == "" {
+				continue
+			}
+			if _, err := c.getTaxRate(ctx, tax.TaxRateDetails.TaxRate, rates); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return summarizeTax(invoices, rates, interval), nil
+}
+
+// summarizeTax groups the taxes of paid invoices by bucket and tax rate,
+// oldest bucket first and largest tax first within a bucket. Rates missing
+// from rates keep only their ID.
+func summarizeTax(invoices []*stripe.Invoice, rates map[string]*stripe.TaxRate, interval string) []TaxData {
+	type key struct {
+		bucket  time.Time
+		taxRate string
+	}
+	groups := make(map[key]*TaxData)
+	for _, inv := range invoices {
+		bucket := bucketStart(time.Unix(invoicePaidAt(inv), 0), interval)
+		for _, tax := range inv.TotalTaxes {
+			k := key{bucket: bucket}
+			if tax.TaxRateDetails != nil {
+				k.taxRate = tax.TaxRateDetails.TaxRate
+			}
+			td, ok := groups[k]
+			if !ok {
+				td = &TaxData{Time: bucket, TaxRate: k.taxRate}
+				if rate, ok := rates[k.taxRate]; ok {
+					td.DisplayName = rate.DisplayName
+					td.Jurisdiction = rate.Jurisdiction
+					td.Country = rate.Country
+					td.State = rate.State
+					td.Percentage = rate.Percentage
+					td.Inclusive = rate.Inclusive
+				}
+				groups[k] = td
+			}
+			if tax.TaxBehavior == stripe.InvoiceTotalTaxTaxBehaviorInclusive {
+				td.Inclusive = true
+			}
+			td.TaxableAmount += tax.TaxableAmount
+			td.Tax += tax.Amount
+		}
+	}
+
+	result := make([]TaxData, 0, len(groups))
+	for _, td := range groups {
+		result = append(result, *td)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].Time.Equal(result[j].Time) {
+			return result[i].Time.Before(result[j].Time)
+		}
+		return result[i].Tax > result[j].Tax
+	})
+	return result
+}
+
+// getTaxRate fetches a tax rate once per query, caching it in cache
+func (c *Client) getTaxRate(ctx context.Context, id string, cache map[string]*stripe.TaxRate) (*stripe.TaxRate, error) {
+	if rate, ok := cache[id]; ok {
+		return rate, nil
+	}
+	params := &stripe.TaxRateParams{}
+	params.Context = ctx
+	rate, err := taxrate.Get(id, params)
+	if err != nil {
+		return nil, err
+	}
+	cache[id] = rate
+	return rate, nil
+}
+
+// invoiceTax sums every tax on an invoice
+func invoiceTax(inv *stripe.Invoice) int64 {
+	var total int64
+	for _, tax := range inv.TotalTaxes {
+		total += tax.Amount
+	}
+	return total
+}
@@ -0,0 +1,59 @@
+package stripe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+func TestSummarizeTax(t *testing.T) {
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := jan.AddDate(0, 1, 0)
+	paid := func(at time.Time, taxes ...*stripe.InvoiceTotalTax) *stripe.Invoice {
+		return &stripe.Invoice{Created: at.Unix(), TotalTaxes: taxes}
+	}
+	tax := func(rate string, amount, taxable int64, behavior stripe.InvoiceTotalTaxTaxBehavior) *stripe.InvoiceTotalTax {
+		t := &stripe.InvoiceTotalTax{Amount: amount, TaxableAmount: taxable, TaxBehavior: behavior}
+		if rate != "" {
+			t.Type = stripe.InvoiceTotalTaxTypeTaxRateDetails
+			t.TaxRateDetails = &stripe.InvoiceTotalTaxTaxRateDetails{TaxRate: rate}
+		}
+		return t
+	}
+	const exclusive, inclusive = stripe.InvoiceTotalTaxTaxBehaviorExclusive, stripe.InvoiceTotalTaxTaxBehaviorInclusive
+
+	rates := map[string]*stripe.TaxRate{
+		// Manual rates
+		"txr_ca":  {DisplayName: "Sales Tax", Jurisdiction: "CA", Country: "US", State: "CA", Percentage: 7.25},
+		"txr_vat": {DisplayName: "VAT", Country: "DE", Percentage: 19, Inclusive: true},
+	}
+	invoices := []*stripe.Invoice{
+		paid(jan.AddDate(0, 0, 4), tax("txr_ca", 725, 10000, exclusive)),
+		paid(jan.AddDate(0, 0, 19), tax("txr_ca", 145, 2000, exclusive), tax("txr_vat", 160, 840, inclusive)),
+		// Stripe Tax rate that could not be looked up, inclusive price
+		paid(jan.AddDate(0, 0, 21), tax("txr_stripe", 50, 500, inclusive)),
+		// Tax without rate details
+		paid(feb.AddDate(0, 0, 1), tax("", 30, 300, exclusive)),
+	}
+
+	got := summarizeTax(invoices, rates, IntervalMonth)
+	want := []TaxData{
+		{Time: jan, TaxRate: "txr_ca", DisplayName: "Sales Tax", Jurisdiction: "CA", Country: "US", State: "CA", Percentage: 7.25, TaxableAmount: 12000, Tax: 870},
+		{Time: jan, TaxRate: "txr_vat", DisplayName: "VAT", Country: "DE", Percentage: 19, Inclusive: true, TaxableAmount: 840, Tax: 160},
+		{Time: jan, TaxRate: "txr_stripe", Inclusive: true, TaxableAmount: 500, Tax: 50},
+		{Time: feb, TaxableAmount: 300, Tax: 30},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d rows, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("row %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if total := invoiceTax(invoices[1]); total != 305 {
+		t.Errorf("invoiceTax: got %d, want 305", total)
+	}
+}
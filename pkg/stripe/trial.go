@@ -0,0 +1,195 @@
+package stripe
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/invoice"
+)
+
+// TrialConversionData represents trial outcomes for one price
+type TrialConversionData struct {
+	PriceID        string
+	PriceName      string
+	Trials         int64
+	Converted      int64
+	Canceled       int64 // Canceled before the trial ended, or never paid after it
+	Pending        int64 // Trial still running
+	ConversionRate float64
+	AvgTrialDays   float64
+}
+
+// GetTrialConversion follows subscriptions whose trial ends between from and
+// to and reports how many converted to paid, per price and overall
+func (c *Client) GetTrialConversion(ctx context.Context, from, to time.Time) ([]TrialConversionData, error) {
+	stripe.Key = c.key
+
+	subs, err := c.listAllSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only trials whose status doesn't settle the outcome need their first
+	// invoice looked up
+	now := time.Now()
+	firstPaid := make(map[string]bool)
+	for _, s := range subs {
+		if !inTrialWindow(s, from, to) || !needsFirstInvoice(s, now) {
+			continue
+		}
+		paid, err := c.firstInvoicePaid(ctx, s)
+		if err != nil {
+			return nil, err
+		}
+		firstPaid[s.ID] = paid
+	}
+	return summarizeTrials(subs, firstPaid, from, to, now), nil
+}
+
+// inTrialWindow reports whether a subscription's trial ends in [from, to]
+func inTrialWindow(s *stripe.Subscription, from, to time.Time) bool {
+	return s.TrialEnd != 0 && s.TrialEnd >= from.Unix() && s.TrialEnd <= to.Unix()
+}
+
+// firstInvoicePaid reports whether the first invoice after a subscription's
+// trial was paid. Stripe creates it when the trial ends.
+func (c *Client) firstInvoicePaid(ctx context.Context, s *stripe.Subscription) (bool, error) {
+	params := &stripe.InvoiceListParams{
+		Subscription: stripe.String(s.ID),
+		CreatedRange: &stripe.RangeQueryParams{
+			GreaterThanOrEqual: s.TrialEnd,
+			LesserThanOrEqual:  s.TrialEnd + 86400,
+		},
+	}
+	params.Context = ctx
+
+	// Listed newest first, so the first invoice is the last one
+	var first *stripe.Invoice
+	iter := invoice.List(params)
+	for iter.Next() {
+		first = iter.Invoice()
+	}
+	if err := iter.Err(); err != nil {
+		return false, err
+	}
+	return first != nil && first.Status == stripe.InvoiceStatusPaid, nil
+}
+
+// summarizeTrials groups trials ending in [from, to] by the price of the first
+// subscription item. The first row is the total across all prices. firstPaid
+// holds, by subscription ID, whether the first invoice after the trial was
+// paid, for the subscriptions needsFirstInvoice selects.
+func summarizeTrials(subs []*stripe.Subscription, firstPaid map[string]bool, from, to, now time.Time) []TrialConversionData {
+	type tally struct {
+		TrialConversionData
+		trialSeconds int64
+	}
+
+	total := &tally{TrialConversionData: TrialConversionData{PriceName: "All prices"}}
+	byPrice := make(map[string]*tally)
+	for _, s := range subs {
+		if !inTrialWindow(s, from, to) {
+			continue
+		}
+
+		var price *stripe.Price
+		if len(s.Items.Data) > 0 {
+			price = s.Items.Data[0].Price
+		}
+		priceID := ""
+		if price != nil {
+			priceID = price.ID
+		}
+		pt, ok := byPrice[priceID]
+		if !ok {
+			pt = &tally{TrialConversionData: TrialConversionData{PriceID: priceID, PriceName: priceLabel(price)}}
+			byPrice[priceID] = pt
+		}
+
+		outcome := trialOutcome(s, firstPaid[s.ID], now)
+		for _, t := range []*tally{total, pt} {
+			t.Trials++
+			t.trialSeconds += s.TrialEnd - s.TrialStart
+			switch outcome {
+			case trialConverted:
+				t.Converted++
+			case trialCanceled:
+				t.Canceled++
+			default:
+				t.Pending++
+			}
+		}
+	}
+
+	tallies := make([]*tally, 0, len(byPrice))
+	for _, pt := range byPrice {
+		tallies = append(tallies, pt)
+	}
+	sort.Slice(tallies, func(i, j int) bool {
+		return tallies[i].Trials > tallies[j].Trials
+	})
+	tallies = append([]*tally{total}, tallies...)
+
+	result := make([]TrialConversionData, len(tallies))
+	for i, t := range tallies {
+		// Conversion rate only counts trials that have resolved
+		if decided := t.Converted + t.Canceled; decided > 0 {
+			t.ConversionRate = float64(t.Converted) / float64(decided) * 100
+		}
+		if t.Trials > 0 {
+			t.AvgTrialDays = float64(t.trialSeconds) / float64(t.Trials) / 86400
+		}
+		result[i] = t.TrialConversionData
+	}
+	return result
+}
+
+type trialResult int
+
+const (
+	trialPending trialResult = iota
+	trialConverted
+	trialCanceled
+)
+
+// needsFirstInvoice reports whether a trial's outcome depends on its first
+// invoice: it ended, and the subscription is neither active nor canceled
+// before the trial ended
+func needsFirstInvoice(s *stripe.Subscription, now time.Time) bool {
+	if s.CanceledAt > 0 && s.CanceledAt <= s.TrialEnd {
+		return false
+	}
+	if s.TrialEnd > now.Unix() {
+		return false
+	}
+	switch s.Status {
+	case stripe.SubscriptionStatusTrialing, stripe.SubscriptionStatusActive:
+		return false
+	}
+	return true
+}
+
+// trialOutcome classifies a subscription that had a trial. A trial converted
+// when the subscription is active or paid its first invoice after the trial;
+// firstPaid tells the latter.
+func trialOutcome(s *stripe.Subscription, firstPaid bool, now time.Time) trialResult {
+	// Canceled at or before the trial end means the customer never paid
+	if s.CanceledAt > 0 && s.CanceledAt <= s.TrialEnd {
+		return trialCanceled
+	}
+	if s.TrialEnd > now.Unix() || s.Status == stripe.SubscriptionStatusTrialing {
+		return trialPending
+	}
+	if s.Status == stripe.SubscriptionStatusActive || firstPaid {
+		return trialConverted
+	}
+	switch s.Status {
+	case stripe.SubscriptionStatusPaused, stripe.SubscriptionStatusIncomplete, stripe.SubscriptionStatusPastDue:
+		// Waiting on a payment method or retrying the first payment
+		return trialPending
+	}
+	// Unpaid, incomplete_expired, or canceled after the first payment failed
+	return trialCanceled
+}
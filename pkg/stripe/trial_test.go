@@ -0,0 +1,74 @@
+package stripe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+func TestSummarizeTrials(t *testing.T) {
+	now := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	from := now.AddDate(0, -1, 0)
+	to := now.AddDate(0, 1, 0)
+	day := int64(86400)
+
+	price := &stripe.Price{ID: "price_pro", Nickname: "Pro"}
+	sub := func(id string, status stripe.SubscriptionStatus, trialEnd time.Time, canceledAt int64) *stripe.Subscription {
+		return &stripe.Subscription{
+			ID:         id,
+			Status:     status,
+			TrialStart: trialEnd.Unix() - 14*day,
+			TrialEnd:   trialEnd.Unix(),
+			CanceledAt: canceledAt,
+			Items: &stripe.SubscriptionItemList{
+				Data: []*stripe.SubscriptionItem{{Price: price}},
+			},
+		}
+	}
+
+	ended := now.AddDate(0, 0, -5)
+	subs := []*stripe.Subscription{
+		sub("sub_active", stripe.SubscriptionStatusActive, ended, 0),
+		sub("sub_canceled", stripe.SubscriptionStatusCanceled, ended, ended.Unix()-day),
+		sub("sub_trialing", stripe.SubscriptionStatusTrialing, now.AddDate(0, 0, 5), 0),
+		// First invoice paid, a later one failing
+		sub("sub_past_due_paid", stripe.SubscriptionStatusPastDue, ended, 0),
+		// First invoice still being retried
+		sub("sub_past_due", stripe.SubscriptionStatusPastDue, ended, 0),
+		sub("sub_unpaid", stripe.SubscriptionStatusUnpaid, ended, 0),
+		sub("sub_incomplete", stripe.SubscriptionStatusIncomplete, ended, 0),
+		// Canceled after the first payment failed
+		sub("sub_failed", stripe.SubscriptionStatusCanceled, ended, ended.Unix()+3*day),
+		// Paid, then canceled
+		sub("sub_churned", stripe.SubscriptionStatusCanceled, ended, ended.Unix()+3*day),
+		// Trial ended outside the window
+		sub("sub_old", stripe.SubscriptionStatusActive, now.AddDate(0, -3, 0), 0),
+	}
+	firstPaid := map[string]bool{"sub_past_due_paid": true, "sub_churned": true}
+
+	for _, s := range subs {
+		want := s.Status != stripe.SubscriptionStatusActive && s.Status != stripe.SubscriptionStatusTrialing && s.ID != "sub_canceled"
+		if got := needsFirstInvoice(s, now); got != want {
+			t.Errorf("%s: needsFirstInvoice = %v, want %v", s.ID, got, want)
+		}
+	}
+
+	result := summarizeTrials(subs, firstPaid, from, to, now)
+	if len(result) != 2 {
+		t.Fatalf("expected total and one price row, got %d", len(result))
+	}
+	total := result[0]
+	if total.Trials != 9 || total.Converted != 3 || total.Canceled != 3 || total.Pending != 3 {
+		t.Errorf("unexpected totals: %+v", total)
+	}
+	if total.ConversionRate != 50 {
+		t.Errorf("expected 50%% conversion, got %v", total.ConversionRate)
+	}
+	if total.AvgTrialDays != 14 {
+		t.Errorf("expected 14 day trials, got %v", total.AvgTrialDays)
+	}
+	if result[1].PriceName != "Pro" {
+		t.Errorf("expected price row for Pro, got %q", result[1].PriceName)
+	}
+}
@@ -0,0 +1,170 @@
+package stripe
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/invoice"
+)
+
+// UpcomingRevenuePoint represents invoices expected in one future time bucket
+type UpcomingRevenuePoint struct {
+	Time     time.Time
+	Expected int64
+	Invoices int64
+}
+
+// PreviewFallbacks counts the next invoices estimated at list price although
+// a preview was asked for
+type PreviewFallbacks struct {
+	Failed  int64 // Stripe could not preview the invoice
+	Skipped int64 // Beyond MaxInvoicePreviews
+}
+
+// MaxInvoicePreviews caps the preview requests made by one query
+const MaxInvoicePreviews = 100
+
+// upcomingInvoice is one renewal expected for a subscription
+type upcomingInvoice struct {
+	At     int64
+	Amount int64
+}
+
+// GetUpcomingRevenue projects subscription renewals between from and to,
+// starting no earlier than now, from each item's current period end and price
+// interval. Amounts are list prices; with preview set, each subscription's
+// next invoice is replaced by Stripe's upcoming invoice preview, which
+// includes discounts, tax and prorations at the cost of one request per
+// subscription, for up to MaxInvoicePreviews subscriptions. Invoices that are
+// not previewed keep their list price estimate and are counted in the
+// returned fallbacks.
+func (c *Client) GetUpcomingRevenue(ctx context.Context, from, to time.Time, interval string, preview bool) ([]UpcomingRevenuePoint, PreviewFallbacks, error) {
+	stripe.Key = c.key
+
+	if now := time.Now(); from.Before(now) {
+		from = now
+	}
+	var fallbacks PreviewFallbacks
+	subs, err := c.listAllSubscriptions(ctx)
+	if err != nil {
+		return nil, fallbacks, err
+	}
+
+	starts := buckets(from, to, interval)
+	index := bucketIndex(starts)
+	points := make([]UpcomingRevenuePoint, len(starts))
+	for i, b := range starts {
+		points[i].Time = b
+	}
+
+	var previews int
+	for _, s := range subs {
+		switch s.Status {
+		case stripe.SubscriptionStatusActive, stripe.SubscriptionStatusTrialing, stripe.SubscriptionStatusPastDue:
+		default:
+			continue
+		}
+		renewals := subscriptionRenewals(s, from.Unix(), to.Unix())
+		if len(renewals) == 0 {
+			continue
+		}
+		if preview && renewals[0].At == nextRenewal(s) {
+			if previews == MaxInvoicePreviews {
+				fallbacks.Skipped++
+			} else {
+				previews++
+				amount, err := c.previewNextInvoice(ctx, s)
+				switch {
+				case ctx.Err() != nil:
+					return nil, fallbacks, ctx.Err()
+				case err != nil:
+					fallbacks.Failed++
+				default:
+					renewals[0].Amount = amount
+				}
+			}
+		}
+		for _, r := range renewals {
+			if i, ok := index[bucketStart(time.Unix(r.At, 0), interval)]; ok {
+				points[i].Expected += r.Amount
+				points[i].Invoices++
+			}
+		}
+	}
+	return points, fallbacks, nil
+}
+
+// previewNextInvoice returns the amount due on a subscription's next invoice
+func (c *Client) previewNextInvoice(ctx context.Context, s *stripe.Subscription) (int64, error) {
+	params := &stripe.InvoiceCreatePreviewParams{Subscription: stripe.String(s.ID)}
+	params.Context = ctx
+	inv, err := invoice.CreatePreview(params)
+	if err != nil {
+		return 0, err
+	}
+	return inv.AmountDue, nil
+}
+
+// subscriptionRenewals returns the invoices a subscription is expected to
+// create between from and to, one per renewal date, stopping at a scheduled
+// cancellation
+func subscriptionRenewals(s *stripe.Subscription, from, to int64) []upcomingInvoice {
+	until := to
+	if cancelAt := pendingCancelAt(s); cancelAt > 0 && cancelAt <= until {
+		// Nothing is billed when the subscription ends at its period end
+		until = cancelAt - 1
+	}
+
+	amounts := make(map[int64]int64)
+	for _, item := range s.Items.Data {
+		if item.Price == nil || item.Price.Recurring == nil || item.CurrentPeriodEnd == 0 {
+			continue
+		}
+		amount := item.Price.UnitAmount * item.Quantity
+		for at := item.CurrentPeriodEnd; at <= until; at = addInterval(at, item.Price.Recurring) {
+			if at >= from {
+				amounts[at] += amount
+			}
+		}
+	}
+
+	renewals := make([]upcomingInvoice, 0, len(amounts))
+	for at, amount := range amounts {
+		renewals = append(renewals, upcomingInvoice{At: at, Amount: amount})
+	}
+	sort.Slice(renewals, func(i, j int) bool {
+		return renewals[i].At < renewals[j].At
+	})
+	return renewals
+}
+
+// nextRenewal returns the earliest current period end across a subscription's
+// items, which is when its next invoice is created
+func nextRenewal(s *stripe.Subscription) int64 {
+	var next int64
+	for _, item := range s.Items.Data {
+		if item.CurrentPeriodEnd > 0 && (next == 0 || item.CurrentPeriodEnd < next) {
+			next = item.CurrentPeriodEnd
+		}
+	}
+	return next
+}
+
+// addInterval advances t by one billing period of a recurring price
+func addInterval(t int64, r *stripe.PriceRecurring) int64 {
+	count := int(max(r.IntervalCount, 1))
+	ts := time.Unix(t, 0).UTC()
+	switch r.Interval {
+	case stripe.PriceRecurringIntervalYear:
+		ts = ts.AddDate(count, 0, 0)
+	case stripe.PriceRecurringIntervalMonth:
+		ts = ts.AddDate(0, count, 0)
+	case stripe.PriceRecurringIntervalWeek:
+		ts = ts.AddDate(0, 0, 7*count)
+	default:
+		ts = ts.AddDate(0, 0, count)
+	}
+	return ts.Unix()
+}
@@ -0,0 +1,56 @@
+package stripe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+func TestSubscriptionRenewals(t *testing.T) {
+	start := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC).Unix()
+	to := time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC).Unix()
+
+	item := func(amount int64, interval stripe.PriceRecurringInterval, count int64, periodEnd time.Time) *stripe.SubscriptionItem {
+		return &stripe.SubscriptionItem{
+			Quantity:         2,
+			CurrentPeriodEnd: periodEnd.Unix(),
+			Price: &stripe.Price{
+				UnitAmount: amount,
+				Recurring:  &stripe.PriceRecurring{Interval: interval, IntervalCount: count},
+			},
+		}
+	}
+	s := &stripe.Subscription{
+		Status: stripe.SubscriptionStatusActive,
+		Items: &stripe.SubscriptionItemList{Data: []*stripe.SubscriptionItem{
+			item(1000, stripe.PriceRecurringIntervalMonth, 1, start),
+			// Billed every two weeks on its own anchor
+			item(100, stripe.PriceRecurringIntervalWeek, 2, start.AddDate(0, 0, 3)),
+		}},
+	}
+
+	got := subscriptionRenewals(s, from, to)
+	var monthly, biweekly int
+	for _, r := range got {
+		switch r.Amount {
+		case 2000:
+			monthly++
+		case 200:
+			biweekly++
+		default:
+			t.Errorf("unexpected amount %d at %v", r.Amount, time.Unix(r.At, 0).UTC())
+		}
+	}
+	if monthly != 3 || biweekly != 6 {
+		t.Errorf("got %d monthly and %d biweekly renewals, want 3 and 6", monthly, biweekly)
+	}
+
+	// Canceling at period end bills nothing more
+	s.Items.Data = s.Items.Data[:1]
+	s.CancelAtPeriodEnd = true
+	if got := subscriptionRenewals(s, from, to); len(got) != 0 {
+		t.Errorf("got %d renewals after cancel at period end, want 0", len(got))
+	}
+}
@@ -1,12 +1,12 @@
 import React, { ChangeEvent } from 'react';
-import { FieldSet, InlineField, SecretInput } from '@grafana/ui';
+import { FieldSet, InlineField, Input, SecretInput } from '@grafana/ui';
 import { DataSourcePluginOptionsEditorProps } from '@grafana/data';
 import { StripeDataSourceOptions, StripeSecureJsonData } from '../types';
 
 type Props = DataSourcePluginOptionsEditorProps<StripeDataSourceOptions, StripeSecureJsonData>;
 
 export function ConfigEditor({ options, onOptionsChange }: Props) {
-  const { secureJsonFields, secureJsonData } = options;
+  const { jsonData, secureJsonFields, secureJsonData } = options;
 
   const onAPIKeyChange = (event: ChangeEvent<HTMLInputElement>) => {
     onOptionsChange({
@@ -23,20 +23,44 @@ export function ConfigEditor({ options, onOptionsChange }: Props) {
     });
   };
 
+  const onGrossMarginChange = (event: ChangeEvent<HTMLInputElement>) => {
+    const value = parseFloat(event.target.value);
+    onOptionsChange({
+      ...options,
+      jsonData: { ...jsonData, grossMarginPercent: isNaN(value) ? undefined : value },
+    });
+  };
+
   return (
-    <FieldSet label="Stripe API">
-      <InlineField label="API Key" labelWidth={12} tooltip="Your Stripe secret API key (sk_...)">
-        <SecretInput
-          required
-          id="config-editor-api-key"
-          isConfigured={secureJsonFields.apiKey}
-          value={secureJsonData?.apiKey || ''}
-          placeholder="sk_... or rk_... (secret or restricted key)"
-          width={40}
-          onReset={onResetAPIKey}
-          onChange={onAPIKeyChange}
-        />
-      </InlineField>
-    </FieldSet>
+    <>
+      <FieldSet label="Stripe API">
+        <InlineField label="API Key" labelWidth={12} tooltip="Your Stripe secret API key (sk_...)">
+          <SecretInput
+            required
+            id="config-editor-api-key"
+            isConfigured={secureJsonFields.apiKey}
+            value={secureJsonData?.apiKey || ''}
+            placeholder="sk_... or rk_... (secret or restricted key)"
+            width={40}
+            onReset={onResetAPIKey}
+            onChange={onAPIKeyChange}
+          />
+        </InlineField>
+      </FieldSet>
+      <FieldSet label="Metrics">
+        <InlineField label="Gross margin %" labelWidth={16} tooltip="Applied to ARPU when calculating LTV. Must be above 0 and at most 100; defaults to 100.">
+          <Input
+            id="config-editor-gross-margin"
+            type="number"
+            min={0}
+            max={100}
+            value={jsonData.grossMarginPercent ?? ''}
+            placeholder="100"
+            width={12}
+            onChange={onGrossMarginChange}
+          />
+        </InlineField>
+      </FieldSet>
+    </>
   );
 }
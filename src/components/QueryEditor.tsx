@@ -1,14 +1,104 @@
-import React from 'react';
-import { InlineField, Select } from '@grafana/ui';
+import React, { ChangeEvent } from 'react';
+import { InlineField, InlineFieldRow, InlineSwitch, Input, Select } from '@grafana/ui';
 import { QueryEditorProps, SelectableValue } from '@grafana/data';
 import { DataSource } from '../datasource';
-import { StripeDataSourceOptions, StripeQuery, QueryType, QUERY_TYPES } from '../types';
+import {
+  StripeDataSourceOptions,
+  StripeQuery,
+  QueryType,
+  QUERY_TYPES,
+  GroupBy,
+  Interval,
+  RankBy,
+  SortDirection,
+  CHECKOUT_GROUP_BY_OPTIONS,
+  COMMITTED_QUERY_TYPES,
+  CUSTOMER_QUERY_TYPES,
+  EVENT_QUERY_TYPES,
+  GROUPABLE_QUERY_TYPES,
+  GROUP_BY_OPTIONS,
+  INTERVAL_OPTIONS,
+  INTERVAL_QUERY_TYPES,
+  LIVE_EVENT_QUERY_TYPES,
+  PAGINATED_QUERY_TYPES,
+  PREVIEW_QUERY_TYPES,
+  RANKED_QUERY_TYPES,
+  RANK_BY_OPTIONS,
+  SEARCHABLE_QUERY_TYPES,
+  SORT_DIRECTION_OPTIONS,
+  TABLE_QUERY_TYPES,
+} from '../types';
 
 type Props = QueryEditorProps<DataSource, StripeQuery, StripeDataSourceOptions>;
 
 export function QueryEditor({ query, onChange, onRunQuery }: Props) {
   const onQueryTypeChange = (value: SelectableValue<QueryType>) => {
-    onChange({ ...query, queryType: value.value! });
+    // Group by values differ between query types
+    onChange({ ...query, queryType: value.value!, groupBy: undefined });
+    onRunQuery();
+  };
+
+  const onGroupByChange = (value: SelectableValue<GroupBy>) => {
+    onChange({ ...query, groupBy: value.value || undefined });
+    onRunQuery();
+  };
+
+  const onIntervalChange = (value: SelectableValue<Interval>) => {
+    onChange({ ...query, interval: value.value || undefined });
+    onRunQuery();
+  };
+
+  const onExcludePendingChurnChange = (event: React.FormEvent<HTMLInputElement>) => {
+    onChange({ ...query, excludePendingChurn: event.currentTarget.checked || undefined });
+    onRunQuery();
+  };
+
+  const onPreviewChange = (event: React.FormEvent<HTMLInputElement>) => {
+    onChange({ ...query, preview: event.currentTarget.checked || undefined });
+    onRunQuery();
+  };
+
+  const onRankByChange = (value: SelectableValue<RankBy>) => {
+    onChange({ ...query, rankBy: value.value });
+    onRunQuery();
+  };
+
+  const onCustomerIdChange = (event: ChangeEvent<HTMLInputElement>) => {
+    onChange({ ...query, customerId: event.target.value || undefined });
+  };
+
+  const onSearchChange = (event: ChangeEvent<HTMLInputElement>) => {
+    onChange({ ...query, search: event.target.value || undefined });
+  };
+
+  const onLimitChange = (event: ChangeEvent<HTMLInputElement>) => {
+    const limit = parseInt(event.target.value, 10);
+    onChange({ ...query, limit: isNaN(limit) ? undefined : limit });
+  };
+
+  const onStartingAfterChange = (event: ChangeEvent<HTMLInputElement>) => {
+    onChange({ ...query, startingAfter: event.target.value || undefined });
+  };
+
+  const onEventTypeChange = (event: ChangeEvent<HTMLInputElement>) => {
+    onChange({ ...query, eventType: event.target.value || undefined });
+  };
+
+  const onObjectIdChange = (event: ChangeEvent<HTMLInputElement>) => {
+    onChange({ ...query, objectId: event.target.value || undefined });
+  };
+
+  const onMinAmountChange = (event: ChangeEvent<HTMLInputElement>) => {
+    const minAmount = parseFloat(event.target.value);
+    onChange({ ...query, minAmount: isNaN(minAmount) ? undefined : minAmount });
+  };
+
+  const onSortByChange = (event: ChangeEvent<HTMLInputElement>) => {
+    onChange({ ...query, sortBy: event.target.value || undefined });
+  };
+
+  const onSortDirectionChange = (value: SelectableValue<SortDirection>) => {
+    onChange({ ...query, sortDirection: value.value });
     onRunQuery();
   };
 
@@ -19,16 +109,188 @@ export function QueryEditor({ query, onChange, onRunQuery }: Props) {
   }));
 
   const selected = options.find((o) => o.value === query.queryType) || options[0];
+  const groupByOptions = selected.value === 'checkout_sessions' ? CHECKOUT_GROUP_BY_OPTIONS : GROUP_BY_OPTIONS;
+  const groupBy = groupByOptions.find((o) => o.value === (query.groupBy || '')) || groupByOptions[0];
+  const interval = INTERVAL_OPTIONS.find((o) => o.value === (query.interval || '')) || INTERVAL_OPTIONS[0];
+  const rankBy = RANK_BY_OPTIONS.find((o) => o.value === query.rankBy) || RANK_BY_OPTIONS[0];
+  const sortDirection = SORT_DIRECTION_OPTIONS.find((o) => o.value === query.sortDirection) || SORT_DIRECTION_OPTIONS[0];
 
   return (
-    <InlineField label="Metric" labelWidth={12} tooltip="Select the Stripe metric to query">
-      <Select
-        id="query-editor-metric"
-        options={options}
-        value={selected}
-        onChange={onQueryTypeChange}
-        width={40}
-      />
-    </InlineField>
+    <>
+      <InlineFieldRow>
+        <InlineField label="Metric" labelWidth={12} tooltip="Select the Stripe metric to query">
+          <Select
+            id="query-editor-metric"
+            options={options}
+            value={selected}
+            onChange={onQueryTypeChange}
+            width={40}
+          />
+        </InlineField>
+        {GROUPABLE_QUERY_TYPES.includes(selected.value) && (
+          <InlineField label="Group by" labelWidth={12}>
+            <Select id="query-editor-group-by" options={groupByOptions} value={groupBy} onChange={onGroupByChange} width={20} />
+          </InlineField>
+        )}
+        {INTERVAL_QUERY_TYPES.includes(selected.value) && (
+          <InlineField label="Interval" labelWidth={12} tooltip="Bucket size. Auto picks day, week or month from the panel interval.">
+            <Select id="query-editor-interval" options={INTERVAL_OPTIONS} value={interval} onChange={onIntervalChange} width={20} />
+          </InlineField>
+        )}
+        {PREVIEW_QUERY_TYPES.includes(selected.value) && (
+          <InlineField
+            label="Invoice previews"
+            labelWidth={18}
+            tooltip="Use Stripe's upcoming invoice preview for each subscription's next invoice, including discounts, tax and prorations. Makes one request per subscription."
+          >
+            <InlineSwitch id="query-editor-preview" value={!!query.preview} onChange={onPreviewChange} />
+          </InlineField>
+        )}
+        {RANKED_QUERY_TYPES.includes(selected.value) && (
+          <InlineField label="Rank by" labelWidth={12} tooltip="Revenue is the amount paid in the panel time range">
+            <Select id="query-editor-rank-by" options={RANK_BY_OPTIONS} value={rankBy} onChange={onRankByChange} width={20} />
+          </InlineField>
+        )}
+        {CUSTOMER_QUERY_TYPES.includes(selected.value) && (
+          <InlineField label="Customer" labelWidth={12} tooltip="Customer ID (cus_...), template variables such as $customer are supported">
+            <Input
+              id="query-editor-customer-id"
+              value={query.customerId || ''}
+              placeholder="cus_... or $customer"
+              onChange={onCustomerIdChange}
+              onBlur={onRunQuery}
+              width={30}
+            />
+          </InlineField>
+        )}
+        {LIVE_EVENT_QUERY_TYPES.includes(selected.value) && (
+          <InlineField label="Event type" labelWidth={12} tooltip="A single event type, e.g. charge.succeeded. Leave empty for every event.">
+            <Input
+              id="query-editor-live-event-type"
+              value={query.eventType || ''}
+              placeholder="charge.succeeded"
+              onChange={onEventTypeChange}
+              onBlur={onRunQuery}
+              width={30}
+            />
+          </InlineField>
+        )}
+        {COMMITTED_QUERY_TYPES.includes(selected.value) && (
+          <InlineField label="Committed only" labelWidth={16} tooltip="Exclude subscriptions scheduled to cancel">
+            <InlineSwitch
+              id="query-editor-exclude-pending-churn"
+              value={!!query.excludePendingChurn}
+              onChange={onExcludePendingChurnChange}
+            />
+          </InlineField>
+        )}
+      </InlineFieldRow>
+      {EVENT_QUERY_TYPES.includes(selected.value) && (
+        <InlineFieldRow>
+          <InlineField
+            label="Event types"
+            labelWidth={12}
+            tooltip="Comma separated event types, * matches any characters, e.g. charge.dispute.*, customer.subscription.deleted"
+          >
+            <Input
+              id="query-editor-event-type"
+              value={query.eventType || ''}
+              placeholder="invoice.*"
+              onChange={onEventTypeChange}
+              onBlur={onRunQuery}
+              width={40}
+            />
+          </InlineField>
+          <InlineField label="Min amount" labelWidth={12} tooltip="Skip events whose object amount is lower. Events without an amount are kept.">
+            <Input
+              id="query-editor-min-amount"
+              type="number"
+              min={0}
+              value={query.minAmount ?? ''}
+              onChange={onMinAmountChange}
+              onBlur={onRunQuery}
+              width={12}
+            />
+          </InlineField>
+          <InlineField label="Object ID" labelWidth={12} tooltip="Only events about this object, e.g. sub_... or in_...">
+            <Input
+              id="query-editor-object-id"
+              value={query.objectId || ''}
+              onChange={onObjectIdChange}
+              onBlur={onRunQuery}
+              width={30}
+            />
+          </InlineField>
+          {/* Table queries set the limit in the table options row */}
+          {!TABLE_QUERY_TYPES.includes(selected.value) && (
+            <InlineField label="Limit" labelWidth={8} tooltip="Maximum events to return">
+              <Input
+                id="query-editor-event-limit"
+                type="number"
+                min={1}
+                value={query.limit ?? ''}
+                onChange={onLimitChange}
+                onBlur={onRunQuery}
+                width={10}
+              />
+            </InlineField>
+          )}
+        </InlineFieldRow>
+      )}
+      {TABLE_QUERY_TYPES.includes(selected.value) && (
+        <InlineFieldRow>
+          {SEARCHABLE_QUERY_TYPES.includes(selected.value) && (
+            <InlineField label="Search" labelWidth={12} tooltip="Match email or name (at least 3 characters)">
+              <Input
+                id="query-editor-search"
+                value={query.search || ''}
+                onChange={onSearchChange}
+                onBlur={onRunQuery}
+                width={30}
+              />
+            </InlineField>
+          )}
+          <InlineField label="Limit" labelWidth={8} tooltip="Maximum rows to return, up to 1000">
+            <Input
+              id="query-editor-limit"
+              type="number"
+              min={1}
+              max={1000}
+              value={query.limit ?? ''}
+              onChange={onLimitChange}
+              onBlur={onRunQuery}
+              width={10}
+            />
+          </InlineField>
+          {PAGINATED_QUERY_TYPES.includes(selected.value) && (
+            <InlineField label="Starting after" labelWidth={16} tooltip="ID of the last row of the previous page">
+              <Input
+                id="query-editor-starting-after"
+                value={query.startingAfter || ''}
+                onChange={onStartingAfterChange}
+                onBlur={onRunQuery}
+                width={30}
+              />
+            </InlineField>
+          )}
+          <InlineField label="Sort by" labelWidth={10} tooltip="Column name, e.g. mrr. Sorts the rows of the current page.">
+            <Input
+              id="query-editor-sort-by"
+              value={query.sortBy || ''}
+              onChange={onSortByChange}
+              onBlur={onRunQuery}
+              width={20}
+            />
+          </InlineField>
+          <Select
+            id="query-editor-sort-direction"
+            options={SORT_DIRECTION_OPTIONS}
+            value={sortDirection}
+            onChange={onSortDirectionChange}
+            width={16}
+          />
+        </InlineFieldRow>
+      )}
+    </>
   );
 }
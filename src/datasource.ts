@@ -1,11 +1,15 @@
-import { DataSourceInstanceSettings, CoreApp } from '@grafana/data';
-import { DataSourceWithBackend } from '@grafana/runtime';
+import { DataSourceInstanceSettings, CoreApp, ScopedVars } from '@grafana/data';
+import { DataSourceWithBackend, getTemplateSrv } from '@grafana/runtime';
 
 import { StripeQuery, StripeDataSourceOptions, DEFAULT_QUERY } from './types';
 
 export class DataSource extends DataSourceWithBackend<StripeQuery, StripeDataSourceOptions> {
   constructor(instanceSettings: DataSourceInstanceSettings<StripeDataSourceOptions>) {
     super(instanceSettings);
+    // Annotations use the regular query editor with the events query type
+    this.annotations = {
+      getDefaultQuery: () => ({ queryType: 'annotations' }),
+    };
   }
 
   getDefaultQuery(_: CoreApp): Partial<StripeQuery> {
@@ -15,4 +19,15 @@ export class DataSource extends DataSourceWithBackend<StripeQuery, StripeDataSou
   filterQuery(query: StripeQuery): boolean {
     return !!query.queryType;
   }
+
+  applyTemplateVariables(query: StripeQuery, scopedVars: ScopedVars): StripeQuery {
+    const templateSrv = getTemplateSrv();
+    return {
+      ...query,
+      customerId: query.customerId ? templateSrv.replace(query.customerId, scopedVars) : undefined,
+      search: query.search ? templateSrv.replace(query.search, scopedVars) : undefined,
+      eventType: query.eventType ? templateSrv.replace(query.eventType, scopedVars) : undefined,
+      objectId: query.objectId ? templateSrv.replace(query.objectId, scopedVars) : undefined,
+    };
+  }
 }
@@ -4,12 +4,44 @@ import { DataQuery } from '@grafana/schema';
 export type QueryType =
   | 'mrr' | 'arr' | 'subscribers' | 'customers' | 'balance'
   | 'subscriptions' | 'revenue' | 'invoices' | 'charges' | 'products'
-  | 'new_mrr' | 'churned_mrr' | 'net_new_mrr' | 'churn_rate' | 'arpu' | 'trialing' | 'past_due';
+  | 'new_mrr' | 'churned_mrr' | 'net_new_mrr' | 'churn_rate' | 'arpu' | 'trialing' | 'past_due'
+  | 'subscription_status' | 'trial_conversion' | 'cohort_retention'
+  | 'nrr_12m' | 'nrr_1m' | 'grr_12m' | 'grr_1m'
+  | 'ltv' | 'avg_customer_lifetime' | 'customer_ltv'
+  | 'logo_churn_rate' | 'revenue_churn_rate' | 'voluntary_churn_rate' | 'involuntary_churn_rate' | 'churn_reasons'
+  | 'pending_churn' | 'customer_list' | 'top_customers' | 'customer_concentration'
+  | 'customer_detail' | 'recognized_revenue' | 'invoice_lines' | 'tax' | 'credit_notes'
+  | 'coupons' | 'checkout_sessions' | 'quotes' | 'subscription_schedules' | 'contracted_mrr'
+  | 'upcoming_revenue' | 'annotations' | 'events' | 'live_events' | 'live_gross_volume';
+
+export type GroupBy = '' | 'product' | 'price' | 'payment_link';
 
 export interface StripeQuery extends DataQuery {
   queryType: QueryType;
+  groupBy?: GroupBy;
+  excludePendingChurn?: boolean;
+  // Table options
+  search?: string;
+  limit?: number;
+  startingAfter?: string;
+  sortBy?: string;
+  sortDirection?: SortDirection;
+  rankBy?: RankBy;
+  customerId?: string;
+  interval?: Interval;
+  preview?: boolean;
+  // Event options
+  eventType?: string;
+  minAmount?: number;
+  objectId?: string;
 }
 
+export type Interval = '' | 'day' | 'week' | 'month';
+
+export type RankBy = 'mrr' | 'revenue';
+
+export type SortDirection = 'asc' | 'desc';
+
 export const DEFAULT_QUERY: Partial<StripeQuery> = {
   queryType: 'mrr',
 };
@@ -21,23 +53,128 @@ export const QUERY_TYPES: Array<{ label: string; value: QueryType; description:
   { label: 'New MRR', value: 'new_mrr', description: 'MRR from new subscriptions (last 30 days)' },
   { label: 'Churned MRR', value: 'churned_mrr', description: 'MRR lost from cancellations (last 30 days)' },
   { label: 'Net New MRR', value: 'net_new_mrr', description: 'New MRR minus Churned MRR' },
-  { label: 'Total Revenue', value: 'revenue', description: 'Total revenue from paid invoices' },
+  { label: 'Revenue', value: 'revenue', description: 'Paid, refunded, credited and net revenue over time, with and without tax' },
+  { label: 'Recognized Revenue', value: 'recognized_revenue', description: 'Invoice lines earned over their service period, with deferred revenue balance' },
+  { label: 'Tax Collected', value: 'tax', description: 'Tax on paid invoices by tax rate, jurisdiction and country' },
+  { label: 'Contracted MRR', value: 'contracted_mrr', description: 'MRR projected forward with scheduled phase changes, cancellations and trial ends' },
+  { label: 'Upcoming Revenue', value: 'upcoming_revenue', description: 'Invoice amounts expected from subscription renewals' },
   { label: 'ARPU', value: 'arpu', description: 'Average Revenue Per User' },
+  { label: 'LTV', value: 'ltv', description: 'Customer lifetime value from ARPU, gross margin and churn' },
+  { label: 'NRR % (12 months)', value: 'nrr_12m', description: 'Net revenue retention over the trailing 12 months' },
+  { label: 'NRR % (1 month)', value: 'nrr_1m', description: 'Net revenue retention over the trailing month' },
+  { label: 'GRR % (12 months)', value: 'grr_12m', description: 'Gross revenue retention over the trailing 12 months' },
+  { label: 'GRR % (1 month)', value: 'grr_1m', description: 'Gross revenue retention over the trailing month' },
   // Subscriber metrics
   { label: 'Active Subscribers', value: 'subscribers', description: 'Count of active subscriptions' },
   { label: 'Churn Rate %', value: 'churn_rate', description: 'Subscriber churn rate (last 30 days)' },
+  { label: 'Logo Churn %', value: 'logo_churn_rate', description: 'Share of subscriptions paying at the start of the panel range that ended' },
+  { label: 'Revenue Churn %', value: 'revenue_churn_rate', description: 'Share of MRR paying at the start of the panel range that ended' },
+  { label: 'Voluntary Churn %', value: 'voluntary_churn_rate', description: 'Logo churn from requested cancellations' },
+  { label: 'Involuntary Churn %', value: 'involuntary_churn_rate', description: 'Logo churn from failed or disputed payments' },
   { label: 'Trialing', value: 'trialing', description: 'Subscriptions in trial' },
   { label: 'Past Due', value: 'past_due', description: 'Subscriptions past due' },
   { label: 'Total Customers', value: 'customers', description: 'Total customer count' },
+  { label: 'Avg Customer Lifetime', value: 'avg_customer_lifetime', description: 'Expected customer lifetime in months from churn' },
+  { label: 'Subscription Status', value: 'subscription_status', description: 'Count and MRR for every subscription status' },
+  { label: 'Trial Conversion', value: 'trial_conversion', description: 'Trials ending in the panel range by outcome and price' },
+  { label: 'Cohort Retention', value: 'cohort_retention', description: 'Logo and revenue retention by signup month' },
   // Balance & tables
   { label: 'Available Balance', value: 'balance', description: 'Available balance in USD' },
   { label: 'Subscriptions', value: 'subscriptions', description: 'List of active subscriptions' },
   { label: 'Invoices', value: 'invoices', description: 'List of recent invoices' },
+  { label: 'Invoice Lines', value: 'invoice_lines', description: 'Invoice line items in the panel range with product, discount, tax and service period' },
+  { label: 'Credit Notes', value: 'credit_notes', description: 'Credit notes created in the panel range with amount, reason and status' },
   { label: 'Charges', value: 'charges', description: 'List of recent charges' },
   { label: 'Revenue by Product', value: 'products', description: 'MRR breakdown by product' },
+  { label: 'Customer LTV', value: 'customer_ltv', description: 'Realized lifetime value per customer from paid invoices' },
+  { label: 'Subscription Schedules', value: 'subscription_schedules', description: 'Phases of active and upcoming subscription schedules with their MRR' },
+  { label: 'Pending Churn', value: 'pending_churn', description: 'MRR scheduled to cancel, by month' },
+  { label: 'Customers', value: 'customer_list', description: 'List of customers with MRR and lifetime paid amount' },
+  { label: 'Top Customers', value: 'top_customers', description: 'Largest customers by MRR or revenue in the panel range' },
+  { label: 'Customer Concentration', value: 'customer_concentration', description: 'Share of MRR held by the largest customers and Herfindahl index' },
+  { label: 'Customer Detail', value: 'customer_detail', description: 'Subscriptions, invoices, charges, refunds, disputes and MRR history for one customer' },
+  { label: 'Coupons', value: 'coupons', description: 'Coupons and promotion codes with redemptions and MRR discount on active subscriptions' },
+  { label: 'Checkout Sessions', value: 'checkout_sessions', description: 'Checkout sessions by status and payment status, with conversion rate and revenue' },
+  { label: 'Quotes', value: 'quotes', description: 'Quotes created in the panel range with open value, acceptance rate and time to accept' },
+  { label: 'Churn Reasons', value: 'churn_reasons', description: 'Ended subscriptions by cancellation reason and feedback' },
+  // Events
+  { label: 'Events', value: 'events', description: 'Raw Stripe events with type, object and JSON payload' },
+  { label: 'Event Annotations', value: 'annotations', description: 'Stripe events shaped for dashboard annotations' },
+  // Grafana Live streams
+  { label: 'Live Events', value: 'live_events', description: 'New Stripe events of one type pushed as they happen' },
+  { label: 'Live Gross Volume', value: 'live_gross_volume', description: "Today's gross volume, updated as payments succeed" },
+];
+
+// Query types that accept the groupBy option
+export const GROUPABLE_QUERY_TYPES: QueryType[] = ['nrr_12m', 'nrr_1m', 'grr_12m', 'grr_1m', 'checkout_sessions'];
+
+// Query types that accept the excludePendingChurn option
+export const COMMITTED_QUERY_TYPES: QueryType[] = ['mrr', 'arr'];
+
+// Table query types that accept limit and sort options
+export const TABLE_QUERY_TYPES: QueryType[] = [
+  'subscriptions', 'invoices', 'invoice_lines', 'credit_notes', 'quotes', 'charges', 'customer_list', 'top_customers', 'events',
 ];
 
-export interface StripeDataSourceOptions extends DataSourceJsonData {}
+// Table query types that page through Stripe with a starting_after cursor
+export const PAGINATED_QUERY_TYPES: QueryType[] = [
+  'subscriptions', 'invoices', 'invoice_lines', 'credit_notes', 'charges', 'customer_list', 'events',
+];
+
+// Query types that accept a search term
+export const SEARCHABLE_QUERY_TYPES: QueryType[] = ['customer_list'];
+
+// Query types that read the Events API and accept event filters
+export const EVENT_QUERY_TYPES: QueryType[] = ['events', 'annotations'];
+
+// Streaming query types that take a single event type
+export const LIVE_EVENT_QUERY_TYPES: QueryType[] = ['live_events'];
+
+// Query types that accept the rankBy option
+export const RANKED_QUERY_TYPES: QueryType[] = ['top_customers'];
+
+export const RANK_BY_OPTIONS: Array<{ label: string; value: RankBy }> = [
+  { label: 'MRR', value: 'mrr' },
+  { label: 'Revenue', value: 'revenue' },
+];
+
+// Query types that need a customer ID
+export const CUSTOMER_QUERY_TYPES: QueryType[] = ['customer_detail'];
+
+export const SORT_DIRECTION_OPTIONS: Array<{ label: string; value: SortDirection }> = [
+  { label: 'Ascending', value: 'asc' },
+  { label: 'Descending', value: 'desc' },
+];
+
+// Time series query types that accept the interval option
+export const INTERVAL_QUERY_TYPES: QueryType[] = [
+  'revenue', 'recognized_revenue', 'tax', 'checkout_sessions', 'contracted_mrr', 'upcoming_revenue',
+];
+
+// Query types that can use upcoming invoice previews
+export const PREVIEW_QUERY_TYPES: QueryType[] = ['upcoming_revenue'];
+
+export const INTERVAL_OPTIONS: Array<{ label: string; value: Interval }> = [
+  { label: 'Auto', value: '' },
+  { label: 'Day', value: 'day' },
+  { label: 'Week', value: 'week' },
+  { label: 'Month', value: 'month' },
+];
+
+export const GROUP_BY_OPTIONS: Array<{ label: string; value: GroupBy }> = [
+  { label: 'None', value: '' },
+  { label: 'Product', value: 'product' },
+];
+
+export const CHECKOUT_GROUP_BY_OPTIONS: Array<{ label: string; value: GroupBy }> = [
+  { label: 'None', value: '' },
+  { label: 'Price', value: 'price' },
+  { label: 'Payment link', value: 'payment_link' },
+];
+
+export interface StripeDataSourceOptions extends DataSourceJsonData {
+  grossMarginPercent?: number;
+}
 
 export interface StripeSecureJsonData {
   apiKey?: string;